@@ -7,6 +7,7 @@
 package account
 
 import (
+	"errors"
 	"fmt"
 
 	ethCrypto "github.com/ethereum/go-ethereum/crypto"
@@ -16,6 +17,9 @@ import (
 	"github.com/iotexproject/iotex-address/address"
 )
 
+// ErrWatchOnly is returned when signing with a watch-only account
+var ErrWatchOnly = errors.New("watch-only account cannot sign")
+
 type (
 	// Account is a user account
 	Account interface {
@@ -33,6 +37,8 @@ type (
 		Zero()
 		// SignMessage signs the message using preamble
 		SignMessage(data []byte) ([]byte, error)
+		// SignTypedData signs the EIP-712 typed data
+		SignTypedData(data TypedData) ([]byte, error)
 	}
 
 	account struct {
@@ -145,3 +151,46 @@ func RecoverAddress(messageHash, signature []byte) (address.Address, error) {
 	payload := hash.Hash160b(pub[1:])
 	return address.FromBytes(payload[:])
 }
+
+type watchOnlyAccount struct {
+	address address.Address
+}
+
+// AddressToAccount creates a watch-only account of the address, which holds no key and cannot sign. It is used to
+// build unsigned actions on an online machine, to be signed by the account holding the key elsewhere.
+func AddressToAccount(addr address.Address) Account {
+	return &watchOnlyAccount{addr}
+}
+
+// Address returns the IoTeX address
+func (act *watchOnlyAccount) Address() address.Address {
+	return act.address
+}
+
+// PrivateKey returns nil, as a watch-only account holds no key
+func (act *watchOnlyAccount) PrivateKey() crypto.PrivateKey {
+	return nil
+}
+
+// PublicKey returns nil, as a watch-only account holds no key
+func (act *watchOnlyAccount) PublicKey() crypto.PublicKey {
+	return nil
+}
+
+// Sign returns ErrWatchOnly
+func (act *watchOnlyAccount) Sign([]byte) ([]byte, error) {
+	return nil, ErrWatchOnly
+}
+
+// Verify returns false
+func (act *watchOnlyAccount) Verify([]byte, []byte) bool {
+	return false
+}
+
+// Zero does nothing
+func (act *watchOnlyAccount) Zero() {}
+
+// SignMessage returns ErrWatchOnly
+func (act *watchOnlyAccount) SignMessage([]byte) ([]byte, error) {
+	return nil, ErrWatchOnly
+}
@@ -8,8 +8,10 @@ package account
 
 import (
 	"encoding/hex"
+	"math/big"
 	"testing"
 
+	ethCrypto "github.com/ethereum/go-ethereum/crypto"
 	"github.com/iotexproject/go-pkgs/hash"
 	"github.com/stretchr/testify/assert"
 )
@@ -92,3 +94,29 @@ func TestRecover(t *testing.T) {
 	assert.NoError(err)
 	assert.Equal(Address, addr.String())
 }
+
+func TestAddressToAccount(t *testing.T) {
+	assert := assert.New(t)
+
+	act, err := HexStringToAccount(PrivateKey)
+	assert.NoError(err)
+	watch := AddressToAccount(act.Address())
+	assert.Equal(Address, watch.Address().String())
+	assert.Nil(watch.PublicKey())
+	_, err = watch.Sign([]byte(text))
+	assert.Equal(ErrWatchOnly, err)
+	_, err = watch.SignMessage([]byte(text))
+	assert.Equal(ErrWatchOnly, err)
+}
+
+func TestNormalizeLowS(t *testing.T) {
+	assert := assert.New(t)
+
+	sig, _ := hex.DecodeString("f09c729cc8617aeda344defba6c0eb0eb3ee71732e26f22d1a9fac5beeaa86da3a368417e31779b44e3df4440dfec89a9ecb40567b60228efb67c79672288cef")
+	assert.Equal(sig, NormalizeLowS(sig))
+	highS := append([]byte{}, sig[:32]...)
+	highS = append(highS, new(big.Int).Sub(ethCrypto.S256().Params().N, new(big.Int).SetBytes(sig[32:])).FillBytes(make([]byte, 32))...)
+	assert.Equal(sig, NormalizeLowS(highS))
+	// the signature passed in is not modified
+	assert.NotEqual(sig, highS)
+}
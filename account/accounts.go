@@ -7,12 +7,16 @@
 package account
 
 import (
+	"sort"
+	"sync"
+
 	"github.com/iotexproject/iotex-address/address"
 	"github.com/pkg/errors"
 )
 
-// Accounts type
+// Accounts type, safe for concurrent use
 type Accounts struct {
+	mutex    sync.RWMutex
 	accounts map[string]Account
 }
 
@@ -30,12 +34,16 @@ func (acts *Accounts) Create() (Account, error) {
 	if err != nil {
 		return nil, err
 	}
+	acts.mutex.Lock()
+	defer acts.mutex.Unlock()
 	acts.accounts[acc.Address().String()] = acc
 	return acc, nil
 }
 
 // GetAccount by address
 func (acts *Accounts) GetAccount(addr address.Address) (Account, error) {
+	acts.mutex.RLock()
+	defer acts.mutex.RUnlock()
 	acc, ok := acts.accounts[addr.String()]
 	if !ok {
 		return nil, errors.Errorf("Account %s does not exist", addr)
@@ -46,6 +54,8 @@ func (acts *Accounts) GetAccount(addr address.Address) (Account, error) {
 // AddAccount add an account
 func (acts *Accounts) AddAccount(acc Account) error {
 	addr := acc.Address()
+	acts.mutex.Lock()
+	defer acts.mutex.Unlock()
 	if _, ok := acts.accounts[addr.String()]; ok {
 		return errors.Errorf("Account %s already exists", addr)
 	}
@@ -55,9 +65,38 @@ func (acts *Accounts) AddAccount(acc Account) error {
 
 // RemoveAccount removes an account
 func (acts *Accounts) RemoveAccount(addr address.Address) {
+	acts.mutex.Lock()
+	defer acts.mutex.Unlock()
 	if v, ok := acts.accounts[addr.String()]; ok {
 		// zero the private key
 		v.Zero()
 	}
 	delete(acts.accounts, addr.String())
 }
+
+// Addresses returns the addresses of the accounts, sorted
+func (acts *Accounts) Addresses() []address.Address {
+	acts.mutex.RLock()
+	defer acts.mutex.RUnlock()
+	addrs := make([]address.Address, 0, len(acts.accounts))
+	for _, acc := range acts.accounts {
+		addrs = append(addrs, acc.Address())
+	}
+	sort.Slice(addrs, func(i, j int) bool { return addrs[i].String() < addrs[j].String() })
+	return addrs
+}
+
+// Range calls f on each account in the order of the addresses, until f returns false. The accounts
+// added or removed during the iteration may or may not be visited.
+func (acts *Accounts) Range(f func(Account) bool) {
+	for _, addr := range acts.Addresses() {
+		acc, err := acts.GetAccount(addr)
+		if err != nil {
+			// removed during the iteration
+			continue
+		}
+		if !f(acc) {
+			return
+		}
+	}
+}
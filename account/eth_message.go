@@ -0,0 +1,85 @@
+// Copyright (c) 2020 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package account
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/iotexproject/iotex-address/address"
+)
+
+// HashEthMessage hashes the message using the preamble of Ethereum personal_sign (EIP-191),
+// keccak256("\x19Ethereum Signed Message:\n" || len(message) || message)
+func HashEthMessage(data []byte) hash.Hash256 {
+	return hash.BytesToHash256(accounts.TextHash(data))
+}
+
+// SignEthMessage signs the message as Ethereum personal_sign, and returns the 65-byte signature [R || S || V] with
+// V in {27, 28}, the same as MetaMask
+func SignEthMessage(s Signer, data []byte) ([]byte, error) {
+	h := HashEthMessage(data)
+	sig, err := SignHash(s, h[:])
+	if err != nil {
+		return nil, err
+	}
+	sig[64] += 27
+	return sig, nil
+}
+
+// RecoverEthMessageSigner recovers the signer of the personal_sign signature, in both the io1 and the 0x address
+// forms. V of the signature can be in {0, 1} or {27, 28}.
+func RecoverEthMessageSigner(data, sig []byte) (address.Address, common.Address, error) {
+	if len(sig) != 65 {
+		return nil, common.Address{}, fmt.Errorf("wrong size for signature: got %d, want 65", len(sig))
+	}
+	sig = append([]byte{}, sig...)
+	switch sig[64] {
+	case 0, 1:
+	case 27, 28:
+		sig[64] -= 27
+	default:
+		return nil, common.Address{}, fmt.Errorf("invalid signature recovery id %d", sig[64])
+	}
+	h := HashEthMessage(data)
+	addr, err := RecoverAddress(h[:], sig)
+	if err != nil {
+		return nil, common.Address{}, err
+	}
+	return addr, common.BytesToAddress(addr.Bytes()), nil
+}
+
+// VerifyEthMessage verifies the personal_sign signature is made by the address, which can be an io1 or a 0x address,
+// and returns the signer in both forms
+func VerifyEthMessage(data, sig []byte, addr string) (address.Address, common.Address, error) {
+	expected, err := parseAddress(addr)
+	if err != nil {
+		return nil, common.Address{}, err
+	}
+	ioAddr, ethAddr, err := RecoverEthMessageSigner(data, sig)
+	if err != nil {
+		return nil, common.Address{}, err
+	}
+	if ioAddr.String() != expected.String() {
+		return nil, common.Address{}, fmt.Errorf("message is signed by %s, not %s", ioAddr, addr)
+	}
+	return ioAddr, ethAddr, nil
+}
+
+// parseAddress parses the io1 or the 0x address
+func parseAddress(s string) (address.Address, error) {
+	if strings.HasPrefix(s, "0x") || strings.HasPrefix(s, "0X") {
+		if !common.IsHexAddress(s) {
+			return nil, fmt.Errorf("invalid address %s", s)
+		}
+		return address.FromBytes(common.HexToAddress(s).Bytes())
+	}
+	return address.FromString(s)
+}
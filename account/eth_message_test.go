@@ -0,0 +1,59 @@
+// Copyright (c) 2020 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package account
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	ethCrypto "github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEthMessage(t *testing.T) {
+	require := require.New(t)
+	acc, err := HexStringToAccount(PrivateKey)
+	require.NoError(err)
+	s, err := AsSigner(acc)
+	require.NoError(err)
+	msg := []byte("Sign in to example.com")
+	ethAddr := common.BytesToAddress(acc.Address().Bytes())
+
+	sig, err := SignEthMessage(s, msg)
+	require.NoError(err)
+	// the same as signed by go-ethereum, as personal_sign of MetaMask
+	key, err := ethCrypto.HexToECDSA(PrivateKey)
+	require.NoError(err)
+	expected, err := ethCrypto.Sign(accounts.TextHash(msg), key)
+	require.NoError(err)
+	expected[64] += 27
+	require.Equal(expected, sig)
+
+	for _, addr := range []string{acc.Address().String(), ethAddr.Hex(), ethAddr.String()} {
+		ioAddr, recovered, err := VerifyEthMessage(msg, sig, addr)
+		require.NoError(err)
+		require.Equal(acc.Address().String(), ioAddr.String())
+		require.Equal(ethAddr, recovered)
+	}
+	// V in {0, 1} is taken
+	sig[64] -= 27
+	ioAddr, _, err := RecoverEthMessageSigner(msg, sig)
+	require.NoError(err)
+	require.Equal(acc.Address().String(), ioAddr.String())
+	sig[64] = 5
+	_, _, err = RecoverEthMessageSigner(msg, sig)
+	require.Error(err)
+
+	// the IoTeX preamble is not taken
+	sig, err = acc.SignMessage(msg)
+	require.NoError(err)
+	_, _, err = VerifyEthMessage(msg, sig, acc.Address().String())
+	require.Error(err)
+	_, _, err = VerifyEthMessage(msg, expected, "0x123")
+	require.Error(err)
+}
@@ -0,0 +1,190 @@
+// Copyright (c) 2020 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package account
+
+import (
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	ethCrypto "github.com/ethereum/go-ethereum/crypto"
+	"github.com/iotexproject/go-pkgs/crypto"
+	"github.com/tyler-smith/go-bip39"
+)
+
+const (
+	// IoTeXCoinType is the BIP44 coin type of IoTeX registered in SLIP-0044
+	IoTeXCoinType = 304
+
+	_hardenedOffset = 0x80000000
+)
+
+var (
+	// ErrInvalidMnemonic is returned when the mnemonic has an unknown word or a wrong checksum
+	ErrInvalidMnemonic = errors.New("invalid mnemonic")
+
+	// ErrInvalidDerivedKey is returned when the key derived at an index is invalid, which happens with a probability
+	// lower than 1 in 2^127. The next index should be used instead.
+	ErrInvalidDerivedKey = errors.New("invalid derived key")
+
+	// ErrInvalidIndex is returned when an account index is not below 2^31, the first hardened index of BIP32
+	ErrInvalidIndex = errors.New("invalid account index")
+
+	_masterKeySeed = []byte("Bitcoin seed")
+)
+
+// NewMnemonic generates a BIP39 mnemonic from bits of random entropy. bits must be a multiple of 32 between 128 and
+// 256, which gives 12 to 24 words.
+func NewMnemonic(bits int) (string, error) {
+	entropy, err := bip39.NewEntropy(bits)
+	if err != nil {
+		return "", err
+	}
+	return bip39.NewMnemonic(entropy)
+}
+
+// IsMnemonicValid returns whether the mnemonic has only words of the English word list and a valid checksum
+func IsMnemonicValid(mnemonic string) bool {
+	return bip39.IsMnemonicValid(normalizeMnemonic(mnemonic))
+}
+
+// MnemonicToSeed validates the mnemonic and returns its BIP39 seed. The passphrase is optional, and a different
+// passphrase gives a different seed, hence different accounts.
+func MnemonicToSeed(mnemonic, passphrase string) ([]byte, error) {
+	seed, err := bip39.NewSeedWithErrorChecking(normalizeMnemonic(mnemonic), passphrase)
+	if err != nil {
+		return nil, ErrInvalidMnemonic
+	}
+	return seed, nil
+}
+
+// HDPath returns the BIP44 path of the index-th IoTeX account, m/44'/304'/0'/0/index, which is used by ioctl and
+// IoPay
+func HDPath(index uint32) string {
+	return fmt.Sprintf("m/44'/%d'/0'/0/%d", IoTeXCoinType, index)
+}
+
+// HDWallet derives accounts from a BIP32 master key
+type HDWallet struct {
+	key       []byte
+	chainCode []byte
+}
+
+// NewHDWallet creates the wallet of the BIP32 master key of the seed
+func NewHDWallet(seed []byte) (*HDWallet, error) {
+	if len(seed) < 16 || len(seed) > 64 {
+		return nil, fmt.Errorf("invalid seed length %d", len(seed))
+	}
+	mac := hmac.New(sha512.New, _masterKeySeed)
+	mac.Write(seed)
+	sum := mac.Sum(nil)
+	if !isValidKey(sum[:32]) {
+		return nil, ErrInvalidDerivedKey
+	}
+	return &HDWallet{key: sum[:32], chainCode: sum[32:]}, nil
+}
+
+// NewHDWalletFromMnemonic creates the wallet of the mnemonic and the optional passphrase
+func NewHDWalletFromMnemonic(mnemonic, passphrase string) (*HDWallet, error) {
+	seed, err := MnemonicToSeed(mnemonic, passphrase)
+	if err != nil {
+		return nil, err
+	}
+	return NewHDWallet(seed)
+}
+
+// Derive returns the account at the path, such as m/44'/304'/0'/0/0
+func (w *HDWallet) Derive(path string) (Account, error) {
+	if !strings.HasPrefix(strings.TrimSpace(path), "m/") {
+		return nil, fmt.Errorf("derivation path %s must start with m/", path)
+	}
+	indexes, err := accounts.ParseDerivationPath(path)
+	if err != nil {
+		return nil, err
+	}
+	key, chainCode := w.key, w.chainCode
+	for _, i := range indexes {
+		if key, chainCode, err = deriveChild(key, chainCode, i); err != nil {
+			return nil, err
+		}
+	}
+	sk, err := crypto.BytesToPrivateKey(key)
+	if err != nil {
+		return nil, err
+	}
+	return PrivateKeyToAccount(sk)
+}
+
+// DeriveAccount returns the index-th IoTeX account, at HDPath(index)
+func (w *HDWallet) DeriveAccount(index uint32) (Account, error) {
+	if index >= _hardenedOffset {
+		return nil, ErrInvalidIndex
+	}
+	return w.Derive(HDPath(index))
+}
+
+// DeriveAccounts returns count IoTeX accounts starting from the index start. All the indexes must be below 2^31.
+func (w *HDWallet) DeriveAccounts(start, count uint32) ([]Account, error) {
+	if count == 0 {
+		return nil, errors.New("count of accounts must be positive")
+	}
+	if start >= _hardenedOffset || count > _hardenedOffset-start {
+		return nil, ErrInvalidIndex
+	}
+	// not preallocated, as count is up to 2^31
+	var accs []Account
+	for i := start; i < start+count; i++ {
+		acc, err := w.DeriveAccount(i)
+		if err != nil {
+			return nil, fmt.Errorf("failed to derive account %d: %w", i, err)
+		}
+		accs = append(accs, acc)
+	}
+	return accs, nil
+}
+
+// deriveChild returns the private key and chain code of the child at index, as CKDpriv of BIP32
+func deriveChild(key, chainCode []byte, index uint32) ([]byte, []byte, error) {
+	var data []byte
+	if index >= _hardenedOffset {
+		data = append([]byte{0}, key...)
+	} else {
+		sk, err := ethCrypto.ToECDSA(key)
+		if err != nil {
+			return nil, nil, err
+		}
+		data = ethCrypto.CompressPubkey(&sk.PublicKey)
+	}
+	data = binary.BigEndian.AppendUint32(data, index)
+	mac := hmac.New(sha512.New, chainCode)
+	mac.Write(data)
+	sum := mac.Sum(nil)
+	if !isValidKey(sum[:32]) {
+		return nil, nil, ErrInvalidDerivedKey
+	}
+	child := new(big.Int).SetBytes(sum[:32])
+	child.Add(child, new(big.Int).SetBytes(key))
+	child.Mod(child, ethCrypto.S256().Params().N)
+	if child.Sign() == 0 {
+		return nil, nil, ErrInvalidDerivedKey
+	}
+	return child.FillBytes(make([]byte, 32)), sum[32:], nil
+}
+
+func isValidKey(key []byte) bool {
+	k := new(big.Int).SetBytes(key)
+	return k.Sign() > 0 && k.Cmp(ethCrypto.S256().Params().N) < 0
+}
+
+func normalizeMnemonic(mnemonic string) string {
+	return strings.Join(strings.Fields(strings.ToLower(mnemonic)), " ")
+}
@@ -0,0 +1,119 @@
+// Copyright (c) 2020 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package account
+
+import (
+	"encoding/hex"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+const _testMnemonic = "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about"
+
+func TestMnemonic(t *testing.T) {
+	require := require.New(t)
+	for _, bits := range []int{128, 256} {
+		mnemonic, err := NewMnemonic(bits)
+		require.NoError(err)
+		require.Len(strings.Fields(mnemonic), bits/32*3)
+		require.True(IsMnemonicValid(mnemonic))
+	}
+	_, err := NewMnemonic(100)
+	require.Error(err)
+
+	require.True(IsMnemonicValid(_testMnemonic))
+	require.True(IsMnemonicValid("  Abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon ABOUT "))
+	// wrong checksum
+	require.False(IsMnemonicValid(strings.Replace(_testMnemonic, "about", "abandon", 1)))
+	// unknown word
+	require.False(IsMnemonicValid(strings.Replace(_testMnemonic, "about", "iotex", 1)))
+
+	// BIP39 test vector
+	seed, err := MnemonicToSeed(_testMnemonic, "TREZOR")
+	require.NoError(err)
+	require.Equal("c55257c360c07c72029aebc1b53c05ed0362ada38ead3e3e9efa3708e53495531f09a6987599d18264c1e1c92f2cf141630c7a3c4ab7c81b2f001698e7463b04", hex.EncodeToString(seed))
+	_, err = MnemonicToSeed("abandon about", "")
+	require.Equal(ErrInvalidMnemonic, err)
+}
+
+func TestHDWallet(t *testing.T) {
+	require := require.New(t)
+
+	// BIP32 test vector 1
+	seed, err := hex.DecodeString("000102030405060708090a0b0c0d0e0f")
+	require.NoError(err)
+	w, err := NewHDWallet(seed)
+	require.NoError(err)
+	for _, test := range []struct {
+		path, key string
+	}{
+		{"m/0'", "edb2e14f9ee77d26dd93b4ecede8d16ed408ce149b6cd80b0715a2d911a0afea"},
+		{"m/0'/1", "3c6cb8d0f6a264c91ea8b5030fadaa8e538b020f0a387421a12de9319dc93368"},
+		{"m/0'/1/2'", "cbce0d719ecf7431d88e6a89fa1483e02e35092af60c042b1df2ff59fa424dca"},
+	} {
+		acc, err := w.Derive(test.path)
+		require.NoError(err)
+		require.Equal(test.key, acc.PrivateKey().HexString())
+	}
+	_, err = w.Derive("0/1")
+	require.Error(err)
+	_, err = w.Derive("m/x")
+	require.Error(err)
+	_, err = NewHDWallet(seed[:8])
+	require.Error(err)
+
+	// the same key as Ethereum wallets at the Ethereum path
+	w, err = NewHDWalletFromMnemonic(_testMnemonic, "")
+	require.NoError(err)
+	acc, err := w.Derive("m/44'/60'/0'/0/0")
+	require.NoError(err)
+	require.Equal(common.HexToAddress("0x9858EfFD232B4033E47d90003D41EC34EcaEda94").Bytes(), acc.Address().Bytes())
+
+	// IoTeX accounts
+	require.Equal("m/44'/304'/0'/0/7", HDPath(7))
+	acc, err = w.DeriveAccount(0)
+	require.NoError(err)
+	require.Equal("6165ea15b1abd5b05955a5750a1a8b894dda7ce19923a8c27ef581c9280fd58c", acc.PrivateKey().HexString())
+	accs, err := w.DeriveAccounts(0, 3)
+	require.NoError(err)
+	require.Len(accs, 3)
+	for i, acc := range accs {
+		expected, err := w.Derive(HDPath(uint32(i)))
+		require.NoError(err)
+		require.Equal(expected.Address().String(), acc.Address().String())
+	}
+	require.NotEqual(accs[0].Address().String(), accs[1].Address().String())
+	acc, err = w.DeriveAccount(2)
+	require.NoError(err)
+	require.Equal(accs[2].PrivateKey().HexString(), acc.PrivateKey().HexString())
+	accs, err = w.DeriveAccounts(1<<31-2, 2)
+	require.NoError(err)
+	require.Len(accs, 2)
+
+	// the indexes must be below 2^31
+	_, err = w.DeriveAccount(1 << 31)
+	require.Equal(ErrInvalidIndex, err)
+	_, err = w.DeriveAccounts(1<<31-1, 2)
+	require.Equal(ErrInvalidIndex, err)
+	_, err = w.DeriveAccounts(math.MaxUint32, 2)
+	require.Equal(ErrInvalidIndex, err)
+	_, err = w.DeriveAccounts(0, 0)
+	require.Error(err)
+
+	// the passphrase gives different accounts
+	w, err = NewHDWalletFromMnemonic(_testMnemonic, "TREZOR")
+	require.NoError(err)
+	acc, err = w.DeriveAccount(0)
+	require.NoError(err)
+	require.NotEqual(accs[0].Address().String(), acc.Address().String())
+	_, err = NewHDWalletFromMnemonic("abandon about", "")
+	require.Equal(ErrInvalidMnemonic, err)
+}
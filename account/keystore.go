@@ -0,0 +1,331 @@
+// Copyright (c) 2020 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package account
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/common"
+	ethCrypto "github.com/ethereum/go-ethereum/crypto"
+	"github.com/google/uuid"
+	"github.com/iotexproject/go-pkgs/crypto"
+	"github.com/iotexproject/iotex-address/address"
+	"github.com/pkg/errors"
+)
+
+const (
+	// StandardScryptN is the scrypt N of the keystore files of ioctl and geth, which takes 256MB and about 1 second
+	StandardScryptN = keystore.StandardScryptN
+	// StandardScryptP is the scrypt P of the keystore files of ioctl and geth
+	StandardScryptP = keystore.StandardScryptP
+	// LightScryptN is the scrypt N taking 4MB and about 100ms, for tests and constrained devices
+	LightScryptN = keystore.LightScryptN
+	// LightScryptP is the scrypt P taking 4MB and about 100ms
+	LightScryptP = keystore.LightScryptP
+)
+
+var (
+	// ErrKeyNotFound is returned when the keystore has no key of the address
+	ErrKeyNotFound = errors.New("key not found in keystore")
+	// ErrKeyExists is returned when importing a key already in the keystore
+	ErrKeyExists = errors.New("key already exists in keystore")
+	// ErrDecrypt is returned when the password of the key is wrong
+	ErrDecrypt = keystore.ErrDecrypt
+)
+
+// EncryptAccount encrypts the private key of the account into a Web3 Secret Storage v3 JSON, with scrypt and
+// AES-128-CTR
+func EncryptAccount(acc Account, password string, scryptN, scryptP int) ([]byte, error) {
+	if acc.PrivateKey() == nil {
+		return nil, ErrWatchOnly
+	}
+	id, err := uuid.NewRandom()
+	if err != nil {
+		return nil, err
+	}
+	return encryptKey(acc.PrivateKey(), id, password, scryptN, scryptP)
+}
+
+// DecryptAccount decrypts the keystore JSON into an account. Both scrypt and pbkdf2 keystores of version 1 and 3 are
+// supported.
+func DecryptAccount(keyjson []byte, password string) (Account, error) {
+	key, err := keystore.DecryptKey(keyjson, password)
+	if err != nil {
+		return nil, err
+	}
+	return keyToAccount(key)
+}
+
+// ChangeKeystorePassword decrypts the keystore JSON with the password, and encrypts it again with the new password.
+// The key ID is kept.
+func ChangeKeystorePassword(keyjson []byte, password, newPassword string, scryptN, scryptP int) ([]byte, error) {
+	key, err := keystore.DecryptKey(keyjson, password)
+	if err != nil {
+		return nil, err
+	}
+	acc, err := keyToAccount(key)
+	if err != nil {
+		return nil, err
+	}
+	defer acc.Zero()
+	return encryptKey(acc.PrivateKey(), key.Id, newPassword, scryptN, scryptP)
+}
+
+func encryptKey(sk crypto.PrivateKey, id uuid.UUID, password string, scryptN, scryptP int) ([]byte, error) {
+	ecdsaKey, err := ethCrypto.ToECDSA(sk.Bytes())
+	if err != nil {
+		return nil, err
+	}
+	return keystore.EncryptKey(&keystore.Key{
+		Id:         id,
+		Address:    ethCrypto.PubkeyToAddress(ecdsaKey.PublicKey),
+		PrivateKey: ecdsaKey,
+	}, password, scryptN, scryptP)
+}
+
+func keyToAccount(key *keystore.Key) (Account, error) {
+	sk, err := crypto.BytesToPrivateKey(ethCrypto.FromECDSA(key.PrivateKey))
+	if err != nil {
+		return nil, err
+	}
+	return PrivateKeyToAccount(sk)
+}
+
+// KeyStore keeps encrypted keys in a directory, one file per key, in the same format and file naming as the keystore
+// directories of ioctl and geth
+type KeyStore struct {
+	mutex   sync.Mutex
+	dir     string
+	scryptN int
+	scryptP int
+}
+
+// NewKeyStore creates a keystore in the directory, which is created when the first key is stored. The keys are
+// encrypted with scryptN and scryptP.
+func NewKeyStore(dir string, scryptN, scryptP int) *KeyStore {
+	return &KeyStore{
+		dir:     dir,
+		scryptN: scryptN,
+		scryptP: scryptP,
+	}
+}
+
+// Addresses returns the addresses of the keys in the keystore
+func (ks *KeyStore) Addresses() ([]address.Address, error) {
+	ks.mutex.Lock()
+	defer ks.mutex.Unlock()
+	files, err := keyFiles(ks.dir)
+	if err != nil {
+		return nil, err
+	}
+	addrs := make([]address.Address, 0, len(files))
+	for _, f := range files {
+		addrs = append(addrs, f.address)
+	}
+	return addrs, nil
+}
+
+// HasAddress returns whether the keystore has the key of the address
+func (ks *KeyStore) HasAddress(addr address.Address) bool {
+	ks.mutex.Lock()
+	defer ks.mutex.Unlock()
+	_, err := ks.find(addr)
+	return err == nil
+}
+
+// Store encrypts the key of the account with the password, and stores it in the keystore
+func (ks *KeyStore) Store(acc Account, password string) error {
+	keyjson, err := EncryptAccount(acc, password, ks.scryptN, ks.scryptP)
+	if err != nil {
+		return err
+	}
+	ks.mutex.Lock()
+	defer ks.mutex.Unlock()
+	return ks.write(acc.Address(), keyjson)
+}
+
+// Import decrypts the keystore JSON with the password, and stores the key in the keystore encrypted with newPassword
+func (ks *KeyStore) Import(keyjson []byte, password, newPassword string) (address.Address, error) {
+	acc, err := DecryptAccount(keyjson, password)
+	if err != nil {
+		return nil, err
+	}
+	defer acc.Zero()
+	if err := ks.Store(acc, newPassword); err != nil {
+		return nil, err
+	}
+	return acc.Address(), nil
+}
+
+// Export returns the keystore JSON of the address, encrypted with newPassword
+func (ks *KeyStore) Export(addr address.Address, password, newPassword string) ([]byte, error) {
+	ks.mutex.Lock()
+	defer ks.mutex.Unlock()
+	keyjson, err := ks.read(addr)
+	if err != nil {
+		return nil, err
+	}
+	return ChangeKeystorePassword(keyjson, password, newPassword, ks.scryptN, ks.scryptP)
+}
+
+// Unlock decrypts the key of the address into an account. The caller should Zero the account when done.
+func (ks *KeyStore) Unlock(addr address.Address, password string) (Account, error) {
+	ks.mutex.Lock()
+	defer ks.mutex.Unlock()
+	keyjson, err := ks.read(addr)
+	if err != nil {
+		return nil, err
+	}
+	return DecryptAccount(keyjson, password)
+}
+
+// Update changes the password of the key of the address
+func (ks *KeyStore) Update(addr address.Address, password, newPassword string) error {
+	ks.mutex.Lock()
+	defer ks.mutex.Unlock()
+	f, err := ks.find(addr)
+	if err != nil {
+		return err
+	}
+	keyjson, err := os.ReadFile(f.path)
+	if err != nil {
+		return err
+	}
+	keyjson, err = ChangeKeystorePassword(keyjson, password, newPassword, ks.scryptN, ks.scryptP)
+	if err != nil {
+		return err
+	}
+	return writeFile(f.path, keyjson)
+}
+
+// Delete removes the key of the address, after checking the password
+func (ks *KeyStore) Delete(addr address.Address, password string) error {
+	ks.mutex.Lock()
+	defer ks.mutex.Unlock()
+	f, err := ks.find(addr)
+	if err != nil {
+		return err
+	}
+	keyjson, err := os.ReadFile(f.path)
+	if err != nil {
+		return err
+	}
+	if _, err := keystore.DecryptKey(keyjson, password); err != nil {
+		return err
+	}
+	return os.Remove(f.path)
+}
+
+type keyFile struct {
+	path    string
+	address address.Address
+}
+
+// keyFiles returns the key files in the directory, skipping the hidden files and the files not of a key
+func keyFiles(dir string) ([]keyFile, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var files []keyFile
+	for _, e := range entries {
+		if e.IsDir() || strings.HasPrefix(e.Name(), ".") || strings.HasSuffix(e.Name(), "~") {
+			continue
+		}
+		path := filepath.Join(dir, e.Name())
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		var key struct {
+			Address string `json:"address"`
+		}
+		if json.Unmarshal(data, &key) != nil {
+			continue
+		}
+		b, err := hex.DecodeString(strings.TrimPrefix(key.Address, "0x"))
+		if err != nil || len(b) != common.AddressLength {
+			continue
+		}
+		addr, err := address.FromBytes(b)
+		if err != nil {
+			continue
+		}
+		files = append(files, keyFile{path, addr})
+	}
+	return files, nil
+}
+
+func (ks *KeyStore) find(addr address.Address) (keyFile, error) {
+	return findKeyFile(ks.dir, addr)
+}
+
+func findKeyFile(dir string, addr address.Address) (keyFile, error) {
+	files, err := keyFiles(dir)
+	if err != nil {
+		return keyFile{}, err
+	}
+	for _, f := range files {
+		if f.address.String() == addr.String() {
+			return f, nil
+		}
+	}
+	return keyFile{}, errors.Wrap(ErrKeyNotFound, addr.String())
+}
+
+func (ks *KeyStore) read(addr address.Address) ([]byte, error) {
+	f, err := ks.find(addr)
+	if err != nil {
+		return nil, err
+	}
+	return os.ReadFile(f.path)
+}
+
+func (ks *KeyStore) write(addr address.Address, keyjson []byte) error {
+	if _, err := ks.find(addr); err == nil {
+		return errors.Wrap(ErrKeyExists, addr.String())
+	}
+	return writeFile(filepath.Join(ks.dir, keyFileName(addr)), keyjson)
+}
+
+// keyFileName returns the file name of the key as geth, UTC--<created at>--<address hex>
+func keyFileName(addr address.Address) string {
+	ts := time.Now().UTC().Format("2006-01-02T15-04-05.000000000Z")
+	return fmt.Sprintf("UTC--%s--%s", ts, hex.EncodeToString(addr.Bytes()))
+}
+
+// writeFile writes the file atomically, readable by the owner only
+func writeFile(path string, data []byte) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return err
+	}
+	f, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".tmp")
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		os.Remove(f.Name())
+		return err
+	}
+	if err := f.Close(); err != nil {
+		os.Remove(f.Name())
+		return err
+	}
+	return os.Rename(f.Name(), path)
+}
@@ -0,0 +1,146 @@
+// Copyright (c) 2020 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package account
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	// the test vectors of Web3 Secret Storage and geth
+	_pbkdf2Keystore      = `{"crypto":{"cipher":"aes-128-ctr","cipherparams":{"iv":"6087dab2f9fdbbfaddc31a909735c1e6"},"ciphertext":"5318b4d5bcd28de64ee5559e671353e16f075ecae9f99c7a79a38af5f869aa46","kdf":"pbkdf2","kdfparams":{"c":262144,"dklen":32,"prf":"hmac-sha256","salt":"ae3cd4e7013836a3df6bd7241b12db061dbe2c6785853cce422d148a624ce0bd"},"mac":"517ead924a9d0dc3124507e3393d175ce3ff7c1e96529c6c555ce9e51205e9b2"},"id":"3198bc9c-6672-5ab3-d995-4942343ae5b6","version":3}`
+	_pbkdf2KeystoreKey   = "7a28b5ba57c53603b0b07b56bba752f7784bf506fa95edc395f5cf6c7514fe9d"
+	_lightScryptKeystore = `{"address":"45dea0fb0bba44f4fcf290bba71fd57d7117cbb8","crypto":{"cipher":"aes-128-ctr","ciphertext":"b87781948a1befd247bff51ef4063f716cf6c2d3481163e9a8f42e1f9bb74145","cipherparams":{"iv":"dc4926b48a105133d2f16b96833abf1e"},"kdf":"scrypt","kdfparams":{"dklen":32,"n":2,"p":1,"r":8,"salt":"004244bbdc51cadda545b1cfa43cff9ed2ae88e08c61f1479dbb45410722f8f0"},"mac":"39990c1684557447940d4c69e06b1b82b2aceacb43f284df65c956daf3046b85"},"id":"ce541d8d-c79b-40f8-9f8c-20f59616faba","version":3}`
+)
+
+func TestEncryptAccount(t *testing.T) {
+	require := require.New(t)
+
+	acc, err := DecryptAccount([]byte(_pbkdf2Keystore), "testpassword")
+	require.NoError(err)
+	require.Equal(_pbkdf2KeystoreKey, acc.PrivateKey().HexString())
+	_, err = DecryptAccount([]byte(_pbkdf2Keystore), "wrong")
+	require.Equal(ErrDecrypt, err)
+	acc, err = DecryptAccount([]byte(_lightScryptKeystore), "")
+	require.NoError(err)
+	require.Equal(common.HexToAddress("45dea0fb0bba44f4fcf290bba71fd57d7117cbb8").Bytes(), acc.Address().Bytes())
+
+	acc, err = HexStringToAccount(PrivateKey)
+	require.NoError(err)
+	keyjson, err := EncryptAccount(acc, "password", LightScryptN, LightScryptP)
+	require.NoError(err)
+	var v3 struct {
+		Address string `json:"address"`
+		ID      string `json:"id"`
+		Version int    `json:"version"`
+		Crypto  struct {
+			Cipher string `json:"cipher"`
+			KDF    string `json:"kdf"`
+		} `json:"crypto"`
+	}
+	require.NoError(json.Unmarshal(keyjson, &v3))
+	require.Equal(3, v3.Version)
+	require.Equal("aes-128-ctr", v3.Crypto.Cipher)
+	require.Equal("scrypt", v3.Crypto.KDF)
+	require.Equal(hex.EncodeToString(acc.Address().Bytes()), v3.Address)
+	decrypted, err := DecryptAccount(keyjson, "password")
+	require.NoError(err)
+	require.Equal(Address, decrypted.Address().String())
+	require.Equal(PrivateKey, decrypted.PrivateKey().HexString())
+
+	changed, err := ChangeKeystorePassword(keyjson, "password", "new", LightScryptN, LightScryptP)
+	require.NoError(err)
+	_, err = DecryptAccount(changed, "password")
+	require.Equal(ErrDecrypt, err)
+	decrypted, err = DecryptAccount(changed, "new")
+	require.NoError(err)
+	require.Equal(Address, decrypted.Address().String())
+	var changedV3 struct {
+		ID string `json:"id"`
+	}
+	require.NoError(json.Unmarshal(changed, &changedV3))
+	require.Equal(v3.ID, changedV3.ID)
+	_, err = ChangeKeystorePassword(keyjson, "wrong", "new", LightScryptN, LightScryptP)
+	require.Equal(ErrDecrypt, err)
+
+	_, err = EncryptAccount(AddressToAccount(acc.Address()), "password", LightScryptN, LightScryptP)
+	require.Equal(ErrWatchOnly, err)
+}
+
+func TestKeyStore(t *testing.T) {
+	require := require.New(t)
+	dir := filepath.Join(t.TempDir(), "keystore")
+	ks := NewKeyStore(dir, LightScryptN, LightScryptP)
+	addrs, err := ks.Addresses()
+	require.NoError(err)
+	require.Empty(addrs)
+
+	acc, err := HexStringToAccount(PrivateKey)
+	require.NoError(err)
+	require.NoError(ks.Store(acc, "password"))
+	require.True(errors.Is(ks.Store(acc, "password"), ErrKeyExists))
+	require.True(ks.HasAddress(acc.Address()))
+	info, err := os.Stat(dir)
+	require.NoError(err)
+	require.Equal(os.FileMode(0700), info.Mode().Perm())
+
+	// the file is named as geth
+	entries, err := os.ReadDir(dir)
+	require.NoError(err)
+	require.Len(entries, 1)
+	require.True(strings.HasPrefix(entries[0].Name(), "UTC--"))
+	require.True(strings.HasSuffix(entries[0].Name(), hex.EncodeToString(acc.Address().Bytes())))
+	// other files are skipped
+	require.NoError(os.WriteFile(filepath.Join(dir, "README"), []byte("not a key"), 0600))
+	require.NoError(os.WriteFile(filepath.Join(dir, ".hidden"), []byte(_lightScryptKeystore), 0600))
+
+	imported, err := ks.Import([]byte(_lightScryptKeystore), "", "password2")
+	require.NoError(err)
+	require.Equal(common.HexToAddress("45dea0fb0bba44f4fcf290bba71fd57d7117cbb8").Bytes(), imported.Bytes())
+	_, err = ks.Import([]byte(_lightScryptKeystore), "", "password2")
+	require.True(errors.Is(err, ErrKeyExists))
+	addrs, err = ks.Addresses()
+	require.NoError(err)
+	require.Len(addrs, 2)
+
+	unlocked, err := ks.Unlock(imported, "password2")
+	require.NoError(err)
+	require.Equal(imported.String(), unlocked.Address().String())
+	_, err = ks.Unlock(imported, "")
+	require.Equal(ErrDecrypt, err)
+
+	require.NoError(ks.Update(acc.Address(), "password", "new"))
+	_, err = ks.Unlock(acc.Address(), "password")
+	require.Equal(ErrDecrypt, err)
+	unlocked, err = ks.Unlock(acc.Address(), "new")
+	require.NoError(err)
+	require.Equal(PrivateKey, unlocked.PrivateKey().HexString())
+
+	keyjson, err := ks.Export(acc.Address(), "new", "exported")
+	require.NoError(err)
+	exported, err := DecryptAccount(keyjson, "exported")
+	require.NoError(err)
+	require.Equal(PrivateKey, exported.PrivateKey().HexString())
+
+	require.Equal(ErrDecrypt, ks.Delete(acc.Address(), "password"))
+	require.NoError(ks.Delete(acc.Address(), "new"))
+	require.False(ks.HasAddress(acc.Address()))
+	_, err = ks.Unlock(acc.Address(), "new")
+	require.True(errors.Is(err, ErrKeyNotFound))
+	addrs, err = ks.Addresses()
+	require.NoError(err)
+	require.Len(addrs, 1)
+}
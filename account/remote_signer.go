@@ -0,0 +1,260 @@
+// Copyright (c) 2020 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package account
+
+import (
+	"bytes"
+	"context"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/iotexproject/go-pkgs/crypto"
+	"github.com/iotexproject/iotex-address/address"
+	"github.com/pkg/errors"
+)
+
+// _remoteSignerTimeout is the default timeout of a request to the signing daemon
+const _remoteSignerTimeout = 30 * time.Second
+
+type (
+	// RemoteSignerClient is the client of a signing daemon holding the keys. NewHTTPSignerClient talks to the daemon
+	// over HTTP; a gRPC stub of the daemon can implement it to talk over gRPC.
+	RemoteSignerClient interface {
+		// PublicKey returns the public key of the address
+		PublicKey(ctx context.Context, addr address.Address) ([]byte, error)
+		// SignHash signs the digest with the key of the address, and returns the 65-byte signature [R || S || V]
+		SignHash(ctx context.Context, addr address.Address, digest []byte) ([]byte, error)
+	}
+
+	// HTTPSignerOption is an option of the HTTP signer client
+	HTTPSignerOption func(*httpSignerClient)
+
+	httpSignerClient struct {
+		endpoint string
+		client   *http.Client
+		token    string
+	}
+
+	remoteSigner struct {
+		client    RemoteSignerClient
+		address   address.Address
+		publicKey crypto.PublicKey
+	}
+
+	publicKeyResponse struct {
+		Address   string `json:"address"`
+		PublicKey string `json:"publicKey"`
+	}
+
+	signRequest struct {
+		Digest string `json:"digest"`
+	}
+
+	signResponse struct {
+		Signature string `json:"signature"`
+	}
+
+	errorResponse struct {
+		Error string `json:"error"`
+	}
+
+	signerHandler struct {
+		signers map[string]Signer
+		token   string
+	}
+)
+
+// NewRemoteSigner creates a Signer of the address, signing with the key held by the signing daemon. The public key is
+// fetched from the daemon, and every signature is checked against it.
+func NewRemoteSigner(ctx context.Context, client RemoteSignerClient, addr address.Address) (Signer, error) {
+	b, err := client.PublicKey(ctx, addr)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get public key of %s", addr)
+	}
+	pk, err := crypto.BytesToPublicKey(b)
+	if err != nil {
+		return nil, err
+	}
+	if !bytes.Equal(pk.Hash(), addr.Bytes()) {
+		return nil, errors.Errorf("public key %x does not match address %s", b, addr)
+	}
+	return &remoteSigner{
+		client:    client,
+		address:   addr,
+		publicKey: pk,
+	}, nil
+}
+
+func (s *remoteSigner) Address() address.Address { return s.address }
+
+func (s *remoteSigner) PublicKey() crypto.PublicKey { return s.publicKey }
+
+func (s *remoteSigner) SignHash(digest []byte) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), _remoteSignerTimeout)
+	defer cancel()
+	return s.client.SignHash(ctx, s.address, digest)
+}
+
+// WithHTTPClient sets the http.Client to talk to the signing daemon, such as one with mutual TLS
+func WithHTTPClient(c *http.Client) HTTPSignerOption {
+	return func(hc *httpSignerClient) {
+		hc.client = c
+	}
+}
+
+// WithBearerToken sets the bearer token of the requests to the signing daemon
+func WithBearerToken(token string) HTTPSignerOption {
+	return func(hc *httpSignerClient) {
+		hc.token = token
+	}
+}
+
+// NewHTTPSignerClient creates a client of the signing daemon at the endpoint, such as "https://127.0.0.1:8545". The
+// daemon serves
+//
+//	GET  /v1/keys/{address}       returning {"address": "io1...", "publicKey": "<hex>"}
+//	POST /v1/keys/{address}/sign  with {"digest": "<hex>"}, returning {"signature": "<hex>"}
+//
+// and {"error": "..."} with a non-200 status on failure, as the handler of NewSignerHandler.
+func NewHTTPSignerClient(endpoint string, opts ...HTTPSignerOption) RemoteSignerClient {
+	c := &httpSignerClient{
+		endpoint: strings.TrimSuffix(endpoint, "/"),
+		client:   http.DefaultClient,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+func (c *httpSignerClient) PublicKey(ctx context.Context, addr address.Address) ([]byte, error) {
+	var res publicKeyResponse
+	if err := c.do(ctx, http.MethodGet, "/v1/keys/"+addr.String(), nil, &res); err != nil {
+		return nil, err
+	}
+	return hex.DecodeString(strings.TrimPrefix(res.PublicKey, "0x"))
+}
+
+func (c *httpSignerClient) SignHash(ctx context.Context, addr address.Address, digest []byte) ([]byte, error) {
+	var res signResponse
+	req := &signRequest{Digest: hex.EncodeToString(digest)}
+	if err := c.do(ctx, http.MethodPost, "/v1/keys/"+addr.String()+"/sign", req, &res); err != nil {
+		return nil, err
+	}
+	return hex.DecodeString(strings.TrimPrefix(res.Signature, "0x"))
+}
+
+func (c *httpSignerClient) do(ctx context.Context, method, path string, in, out interface{}) error {
+	var body io.Reader
+	if in != nil {
+		data, err := json.Marshal(in)
+		if err != nil {
+			return err
+		}
+		body = bytes.NewReader(data)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, c.endpoint+path, body)
+	if err != nil {
+		return err
+	}
+	if in != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		var e errorResponse
+		if json.Unmarshal(data, &e) == nil && e.Error != "" {
+			return errors.Errorf("signer: %s (%d)", e.Error, resp.StatusCode)
+		}
+		return errors.Errorf("signer: %s", resp.Status)
+	}
+	return json.Unmarshal(data, out)
+}
+
+// NewSignerHandler returns the http.Handler of a signing daemon serving the signers, in the protocol of
+// NewHTTPSignerClient. Requests must carry the bearer token if it is not "".
+func NewSignerHandler(token string, signers ...Signer) http.Handler {
+	h := &signerHandler{
+		signers: make(map[string]Signer),
+		token:   token,
+	}
+	for _, s := range signers {
+		h.signers[s.Address().String()] = s
+	}
+	return h
+}
+
+func (h *signerHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if h.token != "" && subtle.ConstantTimeCompare([]byte(r.Header.Get("Authorization")), []byte("Bearer "+h.token)) != 1 {
+		writeError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+	parts := strings.Split(strings.TrimPrefix(r.URL.Path, "/v1/keys/"), "/")
+	if !strings.HasPrefix(r.URL.Path, "/v1/keys/") || len(parts) > 2 || (len(parts) == 2 && parts[1] != "sign") {
+		writeError(w, http.StatusNotFound, "not found")
+		return
+	}
+	s, ok := h.signers[parts[0]]
+	if !ok {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("key of %s not found", parts[0]))
+		return
+	}
+	switch {
+	case len(parts) == 1 && r.Method == http.MethodGet:
+		writeJSON(w, &publicKeyResponse{
+			Address:   s.Address().String(),
+			PublicKey: s.PublicKey().HexString(),
+		})
+	case len(parts) == 2 && r.Method == http.MethodPost:
+		var req signRequest
+		if err := json.NewDecoder(io.LimitReader(r.Body, 1<<20)).Decode(&req); err != nil {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		digest, err := hex.DecodeString(strings.TrimPrefix(req.Digest, "0x"))
+		if err != nil || len(digest) != 32 {
+			writeError(w, http.StatusBadRequest, "digest must be 32 bytes in hex")
+			return
+		}
+		sig, err := s.SignHash(digest)
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		writeJSON(w, &signResponse{Signature: hex.EncodeToString(sig)})
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+	}
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, msg string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(&errorResponse{Error: msg})
+}
@@ -0,0 +1,93 @@
+// Copyright (c) 2020 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package account
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/iotexproject/go-pkgs/crypto"
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/iotexproject/iotex-address/address"
+	"github.com/stretchr/testify/require"
+)
+
+type badSigner struct {
+	Signer
+}
+
+func (s *badSigner) SignHash(digest []byte) ([]byte, error) {
+	sk, err := crypto.GenerateKey()
+	if err != nil {
+		return nil, err
+	}
+	return sk.Sign(digest)
+}
+
+func TestSigner(t *testing.T) {
+	require := require.New(t)
+	acc, err := HexStringToAccount(PrivateKey)
+	require.NoError(err)
+	s, err := AsSigner(acc)
+	require.NoError(err)
+	_, err = AsSigner(AddressToAccount(acc.Address()))
+	require.Equal(ErrWatchOnly, err)
+
+	sa := SignerAccount(s)
+	require.Nil(sa.PrivateKey())
+	require.Equal(acc.Address().String(), sa.Address().String())
+	same, err := AsSigner(sa)
+	require.NoError(err)
+	require.Equal(s, same)
+	msg := []byte("hello")
+	sig, err := sa.Sign(msg)
+	require.NoError(err)
+	expected, err := acc.Sign(msg)
+	require.NoError(err)
+	require.Equal(expected, sig)
+	require.True(sa.Verify(msg, sig))
+	sig, err = SignMessage(s, msg)
+	require.NoError(err)
+	expected, err = acc.SignMessage(msg)
+	require.NoError(err)
+	require.Equal(expected, sig)
+
+	_, err = SignMessage(&badSigner{s}, msg)
+	require.Error(err)
+}
+
+func TestRemoteSigner(t *testing.T) {
+	require := require.New(t)
+	acc, err := HexStringToAccount(PrivateKey)
+	require.NoError(err)
+	local, err := AsSigner(acc)
+	require.NoError(err)
+	ts := httptest.NewServer(NewSignerHandler("secret", local))
+	defer ts.Close()
+
+	ctx := context.Background()
+	_, err = NewRemoteSigner(ctx, NewHTTPSignerClient(ts.URL), acc.Address())
+	require.Contains(err.Error(), "unauthorized")
+	client := NewHTTPSignerClient(ts.URL, WithBearerToken("secret"))
+	other, err := address.FromString("io1cl6rl2ev5dfa988qmgzg2x4hfazmp9vn2g66ng")
+	require.NoError(err)
+	_, err = NewRemoteSigner(ctx, client, other)
+	require.Contains(err.Error(), "not found")
+
+	s, err := NewRemoteSigner(ctx, client, acc.Address())
+	require.NoError(err)
+	require.Equal(acc.PublicKey().HexString(), s.PublicKey().HexString())
+	h := hash.Hash256b([]byte("hello"))
+	sig, err := SignHash(s, h[:])
+	require.NoError(err)
+	expected, err := acc.PrivateKey().Sign(h[:])
+	require.NoError(err)
+	require.Equal(expected, sig)
+	_, err = s.SignHash([]byte{1, 2, 3})
+	require.Contains(err.Error(), "32 bytes")
+}
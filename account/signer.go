@@ -0,0 +1,167 @@
+// Copyright (c) 2020 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package account
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+
+	ethCrypto "github.com/ethereum/go-ethereum/crypto"
+	"github.com/iotexproject/go-pkgs/crypto"
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/iotexproject/iotex-address/address"
+)
+
+var (
+	_secp256k1N     = ethCrypto.S256().Params().N
+	_secp256k1HalfN = new(big.Int).Rsh(_secp256k1N, 1)
+)
+
+type (
+	// Signer signs digests with a secp256k1 key, which does not need to live in the process memory. It can be backed
+	// by a remote signing daemon, a KMS or a hardware token.
+	Signer interface {
+		// Address returns the IoTeX address of the key
+		Address() address.Address
+		// PublicKey returns the public key
+		PublicKey() crypto.PublicKey
+		// SignHash signs the 32-byte digest, and returns the 65-byte signature [R || S || V] with V in {0, 1}
+		SignHash(digest []byte) ([]byte, error)
+	}
+
+	privateKeySigner struct {
+		key     crypto.PrivateKey
+		address address.Address
+	}
+
+	signerAccount struct {
+		Signer
+	}
+)
+
+// PrivateKeySigner returns the Signer of the private key
+func PrivateKeySigner(key crypto.PrivateKey) (Signer, error) {
+	addr, err := address.FromBytes(key.PublicKey().Hash())
+	if err != nil {
+		return nil, err
+	}
+	return &privateKeySigner{key, addr}, nil
+}
+
+func (s *privateKeySigner) Address() address.Address { return s.address }
+
+func (s *privateKeySigner) PublicKey() crypto.PublicKey { return s.key.PublicKey() }
+
+func (s *privateKeySigner) SignHash(digest []byte) ([]byte, error) { return s.key.Sign(digest) }
+
+// AsSigner returns the account as a Signer. The account created by SignerAccount returns its Signer.
+func AsSigner(acc Account) (Signer, error) {
+	if s, ok := acc.(*signerAccount); ok {
+		return s.Signer, nil
+	}
+	if acc.PrivateKey() == nil {
+		return nil, ErrWatchOnly
+	}
+	return &privateKeySigner{acc.PrivateKey(), acc.Address()}, nil
+}
+
+// SignerAccount returns an Account signing with the Signer, which can be used by iotex.AuthedClient. Its PrivateKey
+// returns nil.
+func SignerAccount(s Signer) Account {
+	return &signerAccount{s}
+}
+
+// PrivateKey returns nil, as the key is held by the signer
+func (act *signerAccount) PrivateKey() crypto.PrivateKey {
+	return nil
+}
+
+// Sign signs the hash of the message
+func (act *signerAccount) Sign(data []byte) ([]byte, error) {
+	h := hash.Hash256b(data)
+	return SignHash(act.Signer, h[:])
+}
+
+// Verify verifies the message using the public key
+func (act *signerAccount) Verify(data []byte, sig []byte) bool {
+	h := hash.Hash256b(data)
+	return act.PublicKey().Verify(h[:], sig)
+}
+
+// Zero does nothing, as the key is held by the signer
+func (act *signerAccount) Zero() {}
+
+// SignMessage signs the message using preamble
+func (act *signerAccount) SignMessage(data []byte) ([]byte, error) {
+	return SignMessage(act.Signer, data)
+}
+
+// SignMessage signs the message using the preamble of IoTeX, the same as Account.SignMessage
+func SignMessage(s Signer, data []byte) ([]byte, error) {
+	h := HashMessage(data)
+	return SignHash(s, h[:])
+}
+
+// SignHash signs the digest with the signer, and checks the signature recovers the public key of the signer, so a
+// faulty or malicious signer is detected before the signature is used. V of the signature is normalized to {0, 1}.
+func SignHash(s Signer, digest []byte) ([]byte, error) {
+	sig, err := s.SignHash(digest)
+	if err != nil {
+		return nil, err
+	}
+	if len(sig) != 65 {
+		return nil, fmt.Errorf("wrong size for signature: got %d, want 65", len(sig))
+	}
+	sig = append([]byte{}, sig...)
+	if sig[64] >= 27 {
+		sig[64] -= 27
+	}
+	pk, err := crypto.RecoverPubkey(digest, sig)
+	if err != nil {
+		return nil, err
+	}
+	if !bytes.Equal(pk.Bytes(), s.PublicKey().Bytes()) {
+		return nil, fmt.Errorf("signature does not match the public key of %s", s.Address())
+	}
+	return sig, nil
+}
+
+// ToRecoverableSignature converts the ECDSA signature (r, s) of the digest, such as one made by a KMS or an HSM, to the
+// 65-byte signature [R || S || V] of IoTeX, with S normalized to the lower half of the curve order, and V the recovery
+// id against the public key
+func ToRecoverableSignature(r, s *big.Int, digest []byte, pk crypto.PublicKey) ([]byte, error) {
+	if r.Sign() <= 0 || s.Sign() <= 0 || r.Cmp(_secp256k1N) >= 0 || s.Cmp(_secp256k1N) >= 0 {
+		return nil, fmt.Errorf("invalid signature: R or S out of range")
+	}
+	sig := make([]byte, 65)
+	r.FillBytes(sig[:32])
+	s.FillBytes(sig[32:64])
+	copy(sig, NormalizeLowS(sig[:64]))
+	for v := byte(0); v < 2; v++ {
+		sig[64] = v
+		recovered, err := crypto.RecoverPubkey(digest, sig)
+		if err == nil && bytes.Equal(recovered.Bytes(), pk.Bytes()) {
+			return sig, nil
+		}
+	}
+	return nil, fmt.Errorf("signature does not match the public key")
+}
+
+// NormalizeLowS returns the 64-byte signature [R || S] with S in the lower half of the curve order, which go-ethereum
+// requires. [R || N-S] is an equally valid signature, so the signatures of the signers not normalizing S can be
+// verified. The signature is returned as is if S is already low.
+func NormalizeLowS(sig []byte) []byte {
+	s := new(big.Int).SetBytes(sig[32:64])
+	if s.Cmp(_secp256k1HalfN) <= 0 {
+		return sig
+	}
+	normalized := make([]byte, 64)
+	copy(normalized, sig[:32])
+	s.Sub(_secp256k1N, s).FillBytes(normalized[32:])
+	return normalized
+}
@@ -0,0 +1,189 @@
+// Copyright (c) 2020 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package account
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/iotexproject/iotex-address/address"
+)
+
+type (
+	// TypedData is the EIP-712 typed structured data, in the JSON format of eth_signTypedData_v4
+	TypedData = apitypes.TypedData
+	// TypedDataDomain is the EIP-712 domain of the typed data
+	TypedDataDomain = apitypes.TypedDataDomain
+	// TypedDataTypes is the types of the typed data, keyed by type name
+	TypedDataTypes = apitypes.Types
+	// TypedDataType is a field of a type of the typed data
+	TypedDataType = apitypes.Type
+	// TypedDataMessage is the message of the typed data
+	TypedDataMessage = apitypes.TypedDataMessage
+)
+
+// NewTypedDataDomain creates the EIP-712 domain of the contract. evmChainID is the EVM network ID of the chain, as
+// iotex.EVMNetworkID returns, such as 4689 for mainnet.
+func NewTypedDataDomain(name, version string, evmChainID uint32, verifyingContract address.Address) TypedDataDomain {
+	domain := TypedDataDomain{
+		Name:    name,
+		Version: version,
+		ChainId: math.NewHexOrDecimal256(int64(evmChainID)),
+	}
+	if verifyingContract != nil {
+		domain.VerifyingContract = common.BytesToAddress(verifyingContract.Bytes()).Hex()
+	}
+	return domain
+}
+
+// HashTypedData returns the EIP-712 digest of the typed data, keccak256("\x19\x01" || domainSeparator ||
+// hashStruct(message)). The io1 addresses of the domain and the message are converted to 0x addresses.
+func HashTypedData(data TypedData) (hash.Hash256, error) {
+	if err := convertTypedDataAddresses(&data); err != nil {
+		return hash.ZeroHash256, err
+	}
+	h, _, err := apitypes.TypedDataAndHash(data)
+	if err != nil {
+		return hash.ZeroHash256, err
+	}
+	return hash.BytesToHash256(h), nil
+}
+
+// SignTypedData signs the EIP-712 digest of the typed data with the signer, and returns the 65-byte signature
+// [R || S || V] with V in {27, 28}, as eth_signTypedData_v4, to be verified by ecrecover of the contracts
+func SignTypedData(s Signer, data TypedData) ([]byte, error) {
+	h, err := HashTypedData(data)
+	if err != nil {
+		return nil, err
+	}
+	sig, err := SignHash(s, h[:])
+	if err != nil {
+		return nil, err
+	}
+	sig[64] += 27
+	return sig, nil
+}
+
+// RecoverTypedDataSigner recovers the address signing the typed data. V of the signature can be in {0, 1} or
+// {27, 28}.
+func RecoverTypedDataSigner(data TypedData, sig []byte) (address.Address, error) {
+	if len(sig) != 65 {
+		return nil, fmt.Errorf("wrong size for signature: got %d, want 65", len(sig))
+	}
+	h, err := HashTypedData(data)
+	if err != nil {
+		return nil, err
+	}
+	sig = append([]byte{}, sig...)
+	if sig[64] >= 27 {
+		sig[64] -= 27
+	}
+	return RecoverAddress(h[:], sig)
+}
+
+// SignTypedData signs the typed data with the private key, as the package-level SignTypedData
+func (act *account) SignTypedData(data TypedData) ([]byte, error) {
+	return SignTypedData(&privateKeySigner{act.private, act.address}, data)
+}
+
+// SignTypedData returns ErrWatchOnly
+func (act *watchOnlyAccount) SignTypedData(TypedData) ([]byte, error) {
+	return nil, ErrWatchOnly
+}
+
+// SignTypedData signs the typed data with the signer
+func (act *signerAccount) SignTypedData(data TypedData) ([]byte, error) {
+	return SignTypedData(act.Signer, data)
+}
+
+// convertTypedDataAddresses converts the io1 addresses in the address fields of the domain and the message to 0x
+// addresses, as go-ethereum only takes 0x addresses
+func convertTypedDataAddresses(data *TypedData) error {
+	if data.Domain.VerifyingContract != "" {
+		addr, err := toEthAddressHex(data.Domain.VerifyingContract)
+		if err != nil {
+			return err
+		}
+		data.Domain.VerifyingContract = addr
+	}
+	msg, err := convertStructAddresses(data.Types, data.PrimaryType, data.Message)
+	if err != nil {
+		return err
+	}
+	data.Message = msg
+	return nil
+}
+
+func convertStructAddresses(types TypedDataTypes, typ string, msg map[string]interface{}) (map[string]interface{}, error) {
+	fields, ok := types[typ]
+	if !ok {
+		// let go-ethereum report the unknown type
+		return msg, nil
+	}
+	converted := make(map[string]interface{}, len(msg))
+	for k, v := range msg {
+		converted[k] = v
+	}
+	for _, f := range fields {
+		v, ok := converted[f.Name]
+		if !ok {
+			continue
+		}
+		c, err := convertFieldAddresses(types, f.Type, v)
+		if err != nil {
+			return nil, fmt.Errorf("field %s: %w", f.Name, err)
+		}
+		converted[f.Name] = c
+	}
+	return converted, nil
+}
+
+func convertFieldAddresses(types TypedDataTypes, typ string, v interface{}) (interface{}, error) {
+	if n := len(typ); n > 2 && typ[n-1] == ']' {
+		// array of the element type, such as address[] or Person[2]
+		arr, ok := v.([]interface{})
+		if !ok {
+			return v, nil
+		}
+		elem := typ[:strings.LastIndexByte(typ, '[')]
+		converted := make([]interface{}, len(arr))
+		for i := range arr {
+			c, err := convertFieldAddresses(types, elem, arr[i])
+			if err != nil {
+				return nil, err
+			}
+			converted[i] = c
+		}
+		return converted, nil
+	}
+	switch val := v.(type) {
+	case string:
+		if typ != "address" {
+			return v, nil
+		}
+		return toEthAddressHex(val)
+	case map[string]interface{}:
+		return convertStructAddresses(types, typ, val)
+	}
+	return v, nil
+}
+
+// toEthAddressHex converts the io1 address to the 0x address, and returns 0x addresses as is
+func toEthAddressHex(s string) (string, error) {
+	if strings.HasPrefix(s, "0x") || strings.HasPrefix(s, "0X") {
+		return s, nil
+	}
+	addr, err := address.FromString(s)
+	if err != nil {
+		return "", err
+	}
+	return common.BytesToAddress(addr.Bytes()).Hex(), nil
+}
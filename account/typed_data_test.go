@@ -0,0 +1,103 @@
+// Copyright (c) 2020 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package account
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/iotexproject/iotex-address/address"
+	"github.com/stretchr/testify/require"
+)
+
+// the example of EIP-712, signed by the private key keccak256("cow")
+const (
+	_mailTypedData = `{
+	"types": {
+		"EIP712Domain": [
+			{"name": "name", "type": "string"},
+			{"name": "version", "type": "string"},
+			{"name": "chainId", "type": "uint256"},
+			{"name": "verifyingContract", "type": "address"}
+		],
+		"Person": [
+			{"name": "name", "type": "string"},
+			{"name": "wallet", "type": "address"}
+		],
+		"Mail": [
+			{"name": "from", "type": "Person"},
+			{"name": "to", "type": "Person"},
+			{"name": "contents", "type": "string"}
+		]
+	},
+	"primaryType": "Mail",
+	"domain": {
+		"name": "Ether Mail",
+		"version": "1",
+		"chainId": 1,
+		"verifyingContract": "0xCcCCccccCCCCcCCCCCCcCcCccCcCCCcCcccccccC"
+	},
+	"message": {
+		"from": {"name": "Cow", "wallet": "0xCD2a3d9F938E13CD947Ec05AbC7FE734Df8DD826"},
+		"to": {"name": "Bob", "wallet": "0xbBbBBBBbbBBBbbbBbbBbbbbBBbBbbbbBbBbbBBbB"},
+		"contents": "Hello, Bob!"
+	}
+}`
+	_mailKey  = "c85ef7d79691fe79573b1a7064c19c1a9819ebdbd1faaab1a8ec92344438aaf4"
+	_mailHash = "be609aee343fb3c4b28e1df9e632fca64fcfaede20f02e86244efddf30957bd2"
+	_mailSig  = "4355c47d63924e8a72e509b65029052eb6c299d53a04e167c5775fd466751c9d07299936d304c153f6443dfa05f40ff007d72911b6f72307f996231605b915621c"
+)
+
+func TestTypedData(t *testing.T) {
+	require := require.New(t)
+	var data TypedData
+	require.NoError(json.Unmarshal([]byte(_mailTypedData), &data))
+	h, err := HashTypedData(data)
+	require.NoError(err)
+	require.Equal(_mailHash, hex.EncodeToString(h[:]))
+
+	acc, err := HexStringToAccount(_mailKey)
+	require.NoError(err)
+	sig, err := acc.SignTypedData(data)
+	require.NoError(err)
+	require.Equal(_mailSig, hex.EncodeToString(sig))
+	addr, err := RecoverTypedDataSigner(data, sig)
+	require.NoError(err)
+	require.Equal(acc.Address().String(), addr.String())
+	sig[64] -= 27
+	addr, err = RecoverTypedDataSigner(data, sig)
+	require.NoError(err)
+	require.Equal(acc.Address().String(), addr.String())
+	_, err = AddressToAccount(acc.Address()).SignTypedData(data)
+	require.Equal(ErrWatchOnly, err)
+
+	// io1 addresses are taken as the 0x addresses
+	toIoAddress := func(hexAddr string) string {
+		a, err := address.FromBytes(common.HexToAddress(hexAddr).Bytes())
+		require.NoError(err)
+		return a.String()
+	}
+	contract, err := address.FromString(toIoAddress("0xCcCCccccCCCCcCCCCCCcCcCccCcCCCcCcccccccC"))
+	require.NoError(err)
+	data.Domain = NewTypedDataDomain("Ether Mail", "1", 1, contract)
+	data.Message["from"] = map[string]interface{}{"name": "Cow", "wallet": acc.Address().String()}
+	data.Message["to"] = map[string]interface{}{"name": "Bob", "wallet": toIoAddress("0xbBbBBBBbbBBBbbbBbbBbbbbBBbBbbbbBbBbbBBbB")}
+	h, err = HashTypedData(data)
+	require.NoError(err)
+	require.Equal(_mailHash, hex.EncodeToString(h[:]))
+
+	// the chain ID is in the domain
+	data.Domain = NewTypedDataDomain("Ether Mail", "1", 4689, contract)
+	addr, err = RecoverTypedDataSigner(data, sig)
+	require.NoError(err)
+	require.NotEqual(acc.Address().String(), addr.String())
+	data.Message["to"] = map[string]interface{}{"name": "Bob", "wallet": "io1invalid"}
+	_, err = HashTypedData(data)
+	require.Error(err)
+}
@@ -0,0 +1,370 @@
+// Copyright (c) 2020 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package account
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/iotexproject/go-pkgs/crypto"
+	"github.com/iotexproject/iotex-address/address"
+	"github.com/pkg/errors"
+)
+
+var (
+	// ErrLocked is returned when using an account of the wallet which is not unlocked
+	ErrLocked = errors.New("account is locked")
+	// ErrLabelExists is returned when the label is already used by another address
+	ErrLabelExists = errors.New("label already exists")
+)
+
+type (
+	// WalletOption is an option of the Wallet
+	WalletOption func(*Wallet)
+
+	// Wallet keeps the keys encrypted in a WalletStore, and the unlocked accounts in memory. It is safe for concurrent
+	// use.
+	Wallet struct {
+		mutex    sync.RWMutex
+		store    WalletStore
+		scryptN  int
+		scryptP  int
+		labels   map[string]string
+		unlocked *Accounts
+		timers   map[string]*time.Timer
+	}
+)
+
+// WithWalletScrypt sets the scrypt parameters to encrypt the keys, default is StandardScryptN and StandardScryptP
+func WithWalletScrypt(scryptN, scryptP int) WalletOption {
+	return func(w *Wallet) {
+		w.scryptN, w.scryptP = scryptN, scryptP
+	}
+}
+
+// NewWallet creates a wallet of the keys in the store
+func NewWallet(store WalletStore, opts ...WalletOption) (*Wallet, error) {
+	labels, err := store.Labels()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to load labels")
+	}
+	w := &Wallet{
+		store:    store,
+		scryptN:  StandardScryptN,
+		scryptP:  StandardScryptP,
+		labels:   labels,
+		unlocked: NewAccounts(),
+		timers:   make(map[string]*time.Timer),
+	}
+	for _, opt := range opts {
+		opt(w)
+	}
+	return w, nil
+}
+
+// Create generates a new account, and stores its key encrypted with the password. The account is locked.
+func (w *Wallet) Create(password, label string) (address.Address, error) {
+	acc, err := NewAccount()
+	if err != nil {
+		return nil, err
+	}
+	defer acc.Zero()
+	if err := w.Import(acc, password, label); err != nil {
+		return nil, err
+	}
+	return acc.Address(), nil
+}
+
+// Import stores the key of the account encrypted with the password. The account is locked.
+func (w *Wallet) Import(acc Account, password, label string) error {
+	keyjson, err := EncryptAccount(acc, password, w.scryptN, w.scryptP)
+	if err != nil {
+		return err
+	}
+	return w.put(acc.Address(), keyjson, label)
+}
+
+// ImportKeystore decrypts the keystore JSON with the password, and stores the key encrypted with newPassword
+func (w *Wallet) ImportKeystore(keyjson []byte, password, newPassword, label string) (address.Address, error) {
+	acc, err := DecryptAccount(keyjson, password)
+	if err != nil {
+		return nil, err
+	}
+	defer acc.Zero()
+	if err := w.Import(acc, newPassword, label); err != nil {
+		return nil, err
+	}
+	return acc.Address(), nil
+}
+
+func (w *Wallet) put(addr address.Address, keyjson []byte, label string) error {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	if _, err := w.store.GetKey(addr); err == nil {
+		return errors.Wrap(ErrKeyExists, addr.String())
+	} else if !errors.Is(err, ErrKeyNotFound) {
+		return err
+	}
+	if a, ok := w.labelAddress(label); ok && label != "" && a != addr.String() {
+		return errors.Wrap(ErrLabelExists, label)
+	}
+	if err := w.store.PutKey(addr, keyjson); err != nil {
+		return err
+	}
+	if label == "" {
+		return nil
+	}
+	return w.setLabel(addr, label)
+}
+
+// Export returns the keystore JSON of the address, encrypted with newPassword
+func (w *Wallet) Export(addr address.Address, password, newPassword string) ([]byte, error) {
+	w.mutex.RLock()
+	defer w.mutex.RUnlock()
+	keyjson, err := w.store.GetKey(addr)
+	if err != nil {
+		return nil, err
+	}
+	return ChangeKeystorePassword(keyjson, password, newPassword, w.scryptN, w.scryptP)
+}
+
+// ChangePassword changes the password of the key of the address
+func (w *Wallet) ChangePassword(addr address.Address, password, newPassword string) error {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	keyjson, err := w.store.GetKey(addr)
+	if err != nil {
+		return err
+	}
+	keyjson, err = ChangeKeystorePassword(keyjson, password, newPassword, w.scryptN, w.scryptP)
+	if err != nil {
+		return err
+	}
+	return w.store.PutKey(addr, keyjson)
+}
+
+// Remove locks the account, and deletes its key and label after checking the password
+func (w *Wallet) Remove(addr address.Address, password string) error {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	keyjson, err := w.store.GetKey(addr)
+	if err != nil {
+		return err
+	}
+	acc, err := DecryptAccount(keyjson, password)
+	if err != nil {
+		return err
+	}
+	acc.Zero()
+	w.lock(addr)
+	if err := w.store.DeleteKey(addr); err != nil {
+		return err
+	}
+	if _, ok := w.labels[addr.String()]; ok {
+		return w.updateLabels(func(labels map[string]string) {
+			delete(labels, addr.String())
+		})
+	}
+	return nil
+}
+
+// Addresses returns the addresses of the keys in the wallet, sorted
+func (w *Wallet) Addresses() ([]address.Address, error) {
+	w.mutex.RLock()
+	defer w.mutex.RUnlock()
+	addrs, err := w.store.Addresses()
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(addrs, func(i, j int) bool { return addrs[i].String() < addrs[j].String() })
+	return addrs, nil
+}
+
+// Range calls f on each address of the wallet and its label, in the order of the addresses, until f returns false
+func (w *Wallet) Range(f func(addr address.Address, label string) bool) error {
+	addrs, err := w.Addresses()
+	if err != nil {
+		return err
+	}
+	for _, addr := range addrs {
+		if !f(addr, w.Label(addr)) {
+			return nil
+		}
+	}
+	return nil
+}
+
+// Label returns the label of the address, or "" if it has no label
+func (w *Wallet) Label(addr address.Address) string {
+	w.mutex.RLock()
+	defer w.mutex.RUnlock()
+	return w.labels[addr.String()]
+}
+
+// SetLabel sets the label of the address, or removes it if label is "". A label is unique in the wallet.
+func (w *Wallet) SetLabel(addr address.Address, label string) error {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	if _, err := w.store.GetKey(addr); err != nil {
+		return err
+	}
+	return w.setLabel(addr, label)
+}
+
+func (w *Wallet) setLabel(addr address.Address, label string) error {
+	if label == "" {
+		return w.updateLabels(func(labels map[string]string) {
+			delete(labels, addr.String())
+		})
+	}
+	if a, ok := w.labelAddress(label); ok && a != addr.String() {
+		return errors.Wrap(ErrLabelExists, label)
+	}
+	return w.updateLabels(func(labels map[string]string) {
+		labels[addr.String()] = label
+	})
+}
+
+// updateLabels updates a copy of the labels by f, and takes it only after it is stored, so that the labels in memory
+// stay the same as in the store
+func (w *Wallet) updateLabels(f func(labels map[string]string)) error {
+	labels := make(map[string]string, len(w.labels)+1)
+	for a, l := range w.labels {
+		labels[a] = l
+	}
+	f(labels)
+	if err := w.store.PutLabels(labels); err != nil {
+		return err
+	}
+	w.labels = labels
+	return nil
+}
+
+func (w *Wallet) labelAddress(label string) (string, bool) {
+	for a, l := range w.labels {
+		if l == label {
+			return a, true
+		}
+	}
+	return "", false
+}
+
+// AddressByLabel returns the address of the label
+func (w *Wallet) AddressByLabel(label string) (address.Address, error) {
+	w.mutex.RLock()
+	defer w.mutex.RUnlock()
+	if a, ok := w.labelAddress(label); ok {
+		return address.FromString(a)
+	}
+	return nil, errors.Errorf("label %s does not exist", label)
+}
+
+// Unlock decrypts the key of the address, and keeps the account in memory until it is locked, or the timeout passes
+// if timeout is not 0. Unlocking an unlocked account resets its timeout.
+func (w *Wallet) Unlock(addr address.Address, password string, timeout time.Duration) error {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	keyjson, err := w.store.GetKey(addr)
+	if err != nil {
+		return err
+	}
+	acc, err := DecryptAccount(keyjson, password)
+	if err != nil {
+		return err
+	}
+	if t, ok := w.timers[addr.String()]; ok {
+		t.Stop()
+		delete(w.timers, addr.String())
+	}
+	if _, err := w.unlocked.GetAccount(addr); err == nil {
+		acc.Zero()
+	} else if err := w.unlocked.AddAccount(acc); err != nil {
+		return err
+	}
+	if timeout > 0 {
+		var t *time.Timer
+		t = time.AfterFunc(timeout, func() {
+			w.mutex.Lock()
+			defer w.mutex.Unlock()
+			// the account may have been unlocked again with a new timeout
+			if w.timers[addr.String()] == t {
+				w.lock(addr)
+			}
+		})
+		w.timers[addr.String()] = t
+	}
+	return nil
+}
+
+// Lock removes the account from memory, and zeroes its private key
+func (w *Wallet) Lock(addr address.Address) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	w.lock(addr)
+}
+
+// LockAll locks all the unlocked accounts
+func (w *Wallet) LockAll() {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	for _, addr := range w.unlocked.Addresses() {
+		w.lock(addr)
+	}
+}
+
+func (w *Wallet) lock(addr address.Address) {
+	if t, ok := w.timers[addr.String()]; ok {
+		t.Stop()
+		delete(w.timers, addr.String())
+	}
+	w.unlocked.RemoveAccount(addr)
+}
+
+// IsUnlocked returns whether the account of the address is unlocked
+func (w *Wallet) IsUnlocked(addr address.Address) bool {
+	_, err := w.unlocked.GetAccount(addr)
+	return err == nil
+}
+
+// Account returns the account of the address if it is unlocked, or ErrLocked. The account signs with the key in the
+// wallet, and returns ErrLocked once the address is locked again. Its PrivateKey returns nil, so the key does not
+// leave the wallet.
+func (w *Wallet) Account(addr address.Address) (Account, error) {
+	acc, err := w.unlocked.GetAccount(addr)
+	if err != nil {
+		return nil, errors.Wrap(ErrLocked, addr.String())
+	}
+	// a copy, which does not point into the private key
+	pk, err := crypto.BytesToPublicKey(acc.PublicKey().Bytes())
+	if err != nil {
+		return nil, err
+	}
+	return SignerAccount(&walletSigner{w: w, address: acc.Address(), publicKey: pk}), nil
+}
+
+// walletSigner signs with the unlocked key of the address in the wallet
+type walletSigner struct {
+	w         *Wallet
+	address   address.Address
+	publicKey crypto.PublicKey
+}
+
+func (s *walletSigner) Address() address.Address { return s.address }
+
+func (s *walletSigner) PublicKey() crypto.PublicKey { return s.publicKey }
+
+// SignHash signs the digest while holding the lock of the wallet, so the key is not zeroed during signing
+func (s *walletSigner) SignHash(digest []byte) ([]byte, error) {
+	s.w.mutex.RLock()
+	defer s.w.mutex.RUnlock()
+	acc, err := s.w.unlocked.GetAccount(s.address)
+	if err != nil {
+		return nil, errors.Wrap(ErrLocked, s.address.String())
+	}
+	return acc.PrivateKey().Sign(digest)
+}
@@ -0,0 +1,200 @@
+// Copyright (c) 2020 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package account
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/iotexproject/iotex-address/address"
+	"github.com/pkg/errors"
+)
+
+const _labelsFile = "labels.json"
+
+var (
+	_labelsKey = []byte("labels")
+	_keyPrefix = []byte("key-")
+)
+
+type (
+	// WalletStore persists the encrypted keys and the labels of a Wallet. The Wallet serializes the calls.
+	WalletStore interface {
+		// Addresses returns the addresses of the stored keys
+		Addresses() ([]address.Address, error)
+		// GetKey returns the keystore JSON of the address, or ErrKeyNotFound
+		GetKey(addr address.Address) ([]byte, error)
+		// PutKey stores the keystore JSON of the address, replacing the existing one
+		PutKey(addr address.Address, keyjson []byte) error
+		// DeleteKey deletes the keystore JSON of the address
+		DeleteKey(addr address.Address) error
+		// Labels returns the labels of the addresses
+		Labels() (map[string]string, error)
+		// PutLabels stores the labels of the addresses
+		PutLabels(labels map[string]string) error
+	}
+
+	// KVStore is an embedded key-value database, such as the ethdb.KeyValueStore of go-ethereum backed by leveldb,
+	// pebble or memory
+	KVStore interface {
+		ethdb.KeyValueReader
+		ethdb.KeyValueWriter
+		ethdb.Iteratee
+	}
+
+	dirWalletStore struct {
+		dir string
+	}
+
+	kvWalletStore struct {
+		db     KVStore
+		prefix []byte
+	}
+)
+
+// NewDirWalletStore stores the keys in a keystore directory as KeyStore, readable by ioctl and geth, and the labels
+// in labels.json of the directory
+func NewDirWalletStore(dir string) WalletStore {
+	return &dirWalletStore{dir}
+}
+
+func (s *dirWalletStore) Addresses() ([]address.Address, error) {
+	files, err := keyFiles(s.dir)
+	if err != nil {
+		return nil, err
+	}
+	addrs := make([]address.Address, 0, len(files))
+	for _, f := range files {
+		addrs = append(addrs, f.address)
+	}
+	return addrs, nil
+}
+
+func (s *dirWalletStore) GetKey(addr address.Address) ([]byte, error) {
+	f, err := findKeyFile(s.dir, addr)
+	if err != nil {
+		return nil, err
+	}
+	return os.ReadFile(f.path)
+}
+
+func (s *dirWalletStore) PutKey(addr address.Address, keyjson []byte) error {
+	path := filepath.Join(s.dir, keyFileName(addr))
+	f, err := findKeyFile(s.dir, addr)
+	switch {
+	case err == nil:
+		path = f.path
+	case !errors.Is(err, ErrKeyNotFound):
+		return err
+	}
+	return writeFile(path, keyjson)
+}
+
+func (s *dirWalletStore) DeleteKey(addr address.Address) error {
+	f, err := findKeyFile(s.dir, addr)
+	if err != nil {
+		return err
+	}
+	return os.Remove(f.path)
+}
+
+func (s *dirWalletStore) Labels() (map[string]string, error) {
+	labels := make(map[string]string)
+	data, err := os.ReadFile(filepath.Join(s.dir, _labelsFile))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return labels, nil
+		}
+		return nil, err
+	}
+	if err := json.Unmarshal(data, &labels); err != nil {
+		return nil, errors.Wrapf(err, "invalid %s", _labelsFile)
+	}
+	return labels, nil
+}
+
+func (s *dirWalletStore) PutLabels(labels map[string]string) error {
+	data, err := json.Marshal(labels)
+	if err != nil {
+		return err
+	}
+	return writeFile(filepath.Join(s.dir, _labelsFile), data)
+}
+
+// NewKVWalletStore stores the keys and the labels in the key-value database, under the keys starting with prefix
+func NewKVWalletStore(db KVStore, prefix []byte) WalletStore {
+	return &kvWalletStore{db, prefix}
+}
+
+func (s *kvWalletStore) key(parts ...[]byte) []byte {
+	k := append([]byte{}, s.prefix...)
+	for _, p := range parts {
+		k = append(k, p...)
+	}
+	return k
+}
+
+func (s *kvWalletStore) Addresses() ([]address.Address, error) {
+	prefix := s.key(_keyPrefix)
+	it := s.db.NewIterator(prefix, nil)
+	defer it.Release()
+	var addrs []address.Address
+	for it.Next() {
+		addr, err := address.FromBytes(it.Key()[len(prefix):])
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid key %x", it.Key())
+		}
+		addrs = append(addrs, addr)
+	}
+	return addrs, it.Error()
+}
+
+func (s *kvWalletStore) GetKey(addr address.Address) ([]byte, error) {
+	k := s.key(_keyPrefix, addr.Bytes())
+	ok, err := s.db.Has(k)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, errors.Wrap(ErrKeyNotFound, addr.String())
+	}
+	return s.db.Get(k)
+}
+
+func (s *kvWalletStore) PutKey(addr address.Address, keyjson []byte) error {
+	return s.db.Put(s.key(_keyPrefix, addr.Bytes()), keyjson)
+}
+
+func (s *kvWalletStore) DeleteKey(addr address.Address) error {
+	return s.db.Delete(s.key(_keyPrefix, addr.Bytes()))
+}
+
+func (s *kvWalletStore) Labels() (map[string]string, error) {
+	labels := make(map[string]string)
+	ok, err := s.db.Has(s.key(_labelsKey))
+	if err != nil || !ok {
+		return labels, err
+	}
+	data, err := s.db.Get(s.key(_labelsKey))
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, &labels); err != nil {
+		return nil, errors.Wrap(err, "invalid labels")
+	}
+	return labels, nil
+}
+
+func (s *kvWalletStore) PutLabels(labels map[string]string) error {
+	data, err := json.Marshal(labels)
+	if err != nil {
+		return err
+	}
+	return s.db.Put(s.key(_labelsKey), data)
+}
@@ -0,0 +1,198 @@
+// Copyright (c) 2020 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package account
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/ethdb/memorydb"
+	"github.com/iotexproject/iotex-address/address"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccountsConcurrency(t *testing.T) {
+	require := require.New(t)
+	acts := NewAccounts()
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			acc, err := acts.Create()
+			require.NoError(err)
+			_, err = acts.GetAccount(acc.Address())
+			require.NoError(err)
+			acts.Addresses()
+			acts.RemoveAccount(acc.Address())
+			require.NoError(acts.AddAccount(acc))
+		}()
+	}
+	wg.Wait()
+	addrs := acts.Addresses()
+	require.Len(addrs, 10)
+	var visited []address.Address
+	acts.Range(func(acc Account) bool {
+		visited = append(visited, acc.Address())
+		return len(visited) < 5
+	})
+	require.Equal(addrs[:5], visited)
+}
+
+func TestWallet(t *testing.T) {
+	for name, store := range map[string]WalletStore{
+		"dir": NewDirWalletStore(t.TempDir()),
+		"kv":  NewKVWalletStore(memorydb.New(), []byte("wallet-")),
+	} {
+		t.Run(name, func(t *testing.T) {
+			testWallet(t, store)
+		})
+	}
+}
+
+func testWallet(t *testing.T, store WalletStore) {
+	require := require.New(t)
+	w, err := NewWallet(store, WithWalletScrypt(2, 1))
+	require.NoError(err)
+
+	acc, err := HexStringToAccount(PrivateKey)
+	require.NoError(err)
+	require.NoError(w.Import(acc, "password", "alice"))
+	require.True(errors.Is(w.Import(acc, "password", ""), ErrKeyExists))
+	require.Equal(ErrWatchOnly, w.Import(AddressToAccount(acc.Address()), "password", ""))
+	_, err = w.Create("password", "alice")
+	require.True(errors.Is(err, ErrLabelExists))
+	bob, err := w.Create("password2", "bob")
+	require.NoError(err)
+	carol, err := w.ImportKeystore([]byte(_lightScryptKeystore), "", "password3", "")
+	require.NoError(err)
+
+	addrs, err := w.Addresses()
+	require.NoError(err)
+	require.Len(addrs, 3)
+	labels := make(map[string]string)
+	require.NoError(w.Range(func(addr address.Address, label string) bool {
+		labels[addr.String()] = label
+		return true
+	}))
+	require.Equal(map[string]string{
+		acc.Address().String(): "alice",
+		bob.String():           "bob",
+		carol.String():         "",
+	}, labels)
+	addr, err := w.AddressByLabel("bob")
+	require.NoError(err)
+	require.Equal(bob.String(), addr.String())
+	_, err = w.AddressByLabel("dave")
+	require.Error(err)
+	require.True(errors.Is(w.SetLabel(carol, "bob"), ErrLabelExists))
+	require.NoError(w.SetLabel(carol, "carol"))
+	require.NoError(w.SetLabel(bob, ""))
+	require.Equal("", w.Label(bob))
+
+	// the keys and the labels are persisted
+	w, err = NewWallet(store, WithWalletScrypt(2, 1))
+	require.NoError(err)
+	require.Equal("carol", w.Label(carol))
+	require.Equal("alice", w.Label(acc.Address()))
+
+	// lock and unlock
+	_, err = w.Account(acc.Address())
+	require.True(errors.Is(err, ErrLocked))
+	require.Equal(ErrDecrypt, w.Unlock(acc.Address(), "wrong", 0))
+	require.NoError(w.Unlock(acc.Address(), "password", 0))
+	require.True(w.IsUnlocked(acc.Address()))
+	unlocked, err := w.Account(acc.Address())
+	require.NoError(err)
+	require.Nil(unlocked.PrivateKey())
+	require.Equal(acc.Address().String(), unlocked.Address().String())
+	sig, err := unlocked.Sign([]byte("hello"))
+	require.NoError(err)
+	expected, err := acc.Sign([]byte("hello"))
+	require.NoError(err)
+	require.Equal(expected, sig)
+	require.True(unlocked.Verify([]byte("hello"), sig))
+	w.Lock(acc.Address())
+	require.False(w.IsUnlocked(acc.Address()))
+	_, err = w.Account(acc.Address())
+	require.True(errors.Is(err, ErrLocked))
+	// the account taken before stops signing once locked
+	_, err = unlocked.Sign([]byte("hello"))
+	require.True(errors.Is(err, ErrLocked))
+	_, err = unlocked.SignMessage([]byte("hello"))
+	require.True(errors.Is(err, ErrLocked))
+	require.NoError(w.Unlock(acc.Address(), "password", 0))
+	_, err = unlocked.SignMessage([]byte("hello"))
+	require.NoError(err)
+	w.Lock(acc.Address())
+
+	// auto-relock
+	require.NoError(w.Unlock(bob, "password2", 50*time.Millisecond))
+	require.NoError(w.Unlock(carol, "password3", time.Hour))
+	require.True(w.IsUnlocked(bob))
+	require.Eventually(func() bool { return !w.IsUnlocked(bob) }, time.Second, 10*time.Millisecond)
+	require.True(w.IsUnlocked(carol))
+	// unlocking again resets the timeout
+	require.NoError(w.Unlock(carol, "password3", 50*time.Millisecond))
+	require.NoError(w.Unlock(carol, "password3", 0))
+	time.Sleep(100 * time.Millisecond)
+	require.True(w.IsUnlocked(carol))
+	w.LockAll()
+	require.False(w.IsUnlocked(carol))
+
+	// password change, export and removal
+	require.NoError(w.ChangePassword(bob, "password2", "new"))
+	require.Equal(ErrDecrypt, w.Unlock(bob, "password2", 0))
+	keyjson, err := w.Export(bob, "new", "exported")
+	require.NoError(err)
+	exported, err := DecryptAccount(keyjson, "exported")
+	require.NoError(err)
+	require.Equal(bob.String(), exported.Address().String())
+	require.NoError(w.Unlock(acc.Address(), "password", 0))
+	require.Equal(ErrDecrypt, w.Remove(acc.Address(), "wrong"))
+	require.NoError(w.Remove(acc.Address(), "password"))
+	require.False(w.IsUnlocked(acc.Address()))
+	require.Equal("", w.Label(acc.Address()))
+	addrs, err = w.Addresses()
+	require.NoError(err)
+	require.Len(addrs, 2)
+	require.True(errors.Is(w.Unlock(acc.Address(), "password", 0), ErrKeyNotFound))
+}
+
+// failingLabelsStore fails to store the labels
+type failingLabelsStore struct {
+	WalletStore
+	fail bool
+}
+
+func (s *failingLabelsStore) PutLabels(labels map[string]string) error {
+	if s.fail {
+		return errors.New("disk full")
+	}
+	return s.WalletStore.PutLabels(labels)
+}
+
+func TestWalletLabelsStoreError(t *testing.T) {
+	require := require.New(t)
+	store := &failingLabelsStore{WalletStore: NewKVWalletStore(memorydb.New(), []byte("wallet-"))}
+	w, err := NewWallet(store, WithWalletScrypt(LightScryptN, LightScryptP))
+	require.NoError(err)
+	addr, err := w.Create("password", "alice")
+	require.NoError(err)
+
+	// the labels in memory are kept when they fail to be stored
+	store.fail = true
+	require.Error(w.SetLabel(addr, "bob"))
+	require.Equal("alice", w.Label(addr))
+	require.Error(w.SetLabel(addr, ""))
+	require.Equal("alice", w.Label(addr))
+	labels, err := store.Labels()
+	require.NoError(err)
+	require.Equal(map[string]string{addr.String(): "alice"}, labels)
+}
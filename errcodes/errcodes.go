@@ -11,6 +11,7 @@ const (
 	RPCError
 	BadResponse
 	InternalError
+	ExecutionFailed
 )
 
 // ErrorWithCode is an error with an associated code.
@@ -28,6 +29,7 @@ type ewc struct {
 func (e *ewc) Error() string { return e.err.Error() }
 func (e *ewc) Code() Code    { return e.code }
 func (e *ewc) Cause() error  { return e.err }
+func (e *ewc) Unwrap() error { return e.err }
 
 // NewError takes an error and a code return a error associated with the code.
 func NewError(err error, c Code) error {
@@ -126,6 +126,6 @@ func (s *xrc20Service) BalanceOf(ctx context.Context, addr string) (balance *big
 	if err != nil {
 		return
 	}
-	balance = new(big.Int).SetBytes(ret.Raw)
+	err = ret.UnmarshalInto(&balance)
 	return
 }
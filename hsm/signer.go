@@ -0,0 +1,303 @@
+// Copyright (c) 2020 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+// Package hsm signs with secp256k1 keys held by a PKCS#11 token, such as an HSM or SoftHSM2, using CKM_ECDSA, so the
+// keys never leave the token.
+package hsm
+
+import (
+	"encoding/asn1"
+	"math/big"
+	"sync"
+
+	"github.com/iotexproject/go-pkgs/crypto"
+	"github.com/iotexproject/iotex-address/address"
+	"github.com/miekg/pkcs11"
+	"github.com/pkg/errors"
+
+	"github.com/iotexproject/iotex-antenna-go/v2/account"
+)
+
+var (
+	// _oidSecp256k1 is the OID of the secp256k1 curve, the CKA_EC_PARAMS of the keys
+	_oidSecp256k1 = asn1.ObjectIdentifier{1, 3, 132, 0, 10}
+
+	// a PKCS#11 library is initialized once per process, so it is shared by the signers
+	_modulesMutex sync.Mutex
+	_modules      = make(map[string]*module)
+)
+
+type (
+	// Config is the config of the PKCS#11 token and the key
+	Config struct {
+		// Module is the path of the PKCS#11 library, such as /usr/lib/softhsm/libsofthsm2.so
+		Module string
+		// TokenLabel is the label of the token
+		TokenLabel string
+		// PIN is the user PIN of the token
+		PIN string
+		// KeyLabel is the CKA_LABEL of the key pair
+		KeyLabel string
+	}
+
+	// Signer signs with the private key in the PKCS#11 token. It is safe for concurrent use, and should be closed
+	// when done.
+	Signer struct {
+		mutex     sync.Mutex
+		ctx       *pkcs11.Ctx
+		session   pkcs11.SessionHandle
+		key       pkcs11.ObjectHandle
+		address   address.Address
+		publicKey crypto.PublicKey
+		module    string
+	}
+
+	module struct {
+		ctx  *pkcs11.Ctx
+		refs int
+	}
+)
+
+// NewSigner logs in the token, and finds the private key and the public key of the key label. The signer can be used
+// by iotex.NewSignerClient and account.SignMessage.
+func NewSigner(cfg Config) (*Signer, error) {
+	ctx, session, err := open(cfg)
+	if err != nil {
+		return nil, err
+	}
+	s, err := newSigner(ctx, session, cfg.KeyLabel)
+	if err != nil {
+		closeSession(cfg.Module, ctx, session)
+		return nil, err
+	}
+	s.module = cfg.Module
+	return s, nil
+}
+
+func newSigner(ctx *pkcs11.Ctx, session pkcs11.SessionHandle, label string) (*Signer, error) {
+	key, err := findObject(ctx, session, pkcs11.CKO_PRIVATE_KEY, label)
+	if err != nil {
+		return nil, err
+	}
+	pub, err := findObject(ctx, session, pkcs11.CKO_PUBLIC_KEY, label)
+	if err != nil {
+		return nil, err
+	}
+	attrs, err := ctx.GetAttributeValue(session, pub, []*pkcs11.Attribute{
+		pkcs11.NewAttribute(pkcs11.CKA_EC_PARAMS, nil),
+		pkcs11.NewAttribute(pkcs11.CKA_EC_POINT, nil),
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read public key %s", label)
+	}
+	var curve asn1.ObjectIdentifier
+	if _, err := asn1.Unmarshal(attrs[0].Value, &curve); err != nil || !curve.Equal(_oidSecp256k1) {
+		return nil, errors.Errorf("key %s is not on secp256k1", label)
+	}
+	// CKA_EC_POINT is the DER-encoded OCTET STRING of the uncompressed point, while some tokens return the raw point
+	var point []byte
+	if _, err := asn1.Unmarshal(attrs[1].Value, &point); err != nil {
+		point = attrs[1].Value
+	}
+	pk, err := crypto.BytesToPublicKey(point)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid public key %s", label)
+	}
+	addr, err := address.FromBytes(pk.Hash())
+	if err != nil {
+		return nil, err
+	}
+	return &Signer{
+		ctx:       ctx,
+		session:   session,
+		key:       key,
+		address:   addr,
+		publicKey: pk,
+	}, nil
+}
+
+// Address returns the IoTeX address of the key
+func (s *Signer) Address() address.Address { return s.address }
+
+// PublicKey returns the public key
+func (s *Signer) PublicKey() crypto.PublicKey { return s.publicKey }
+
+// SignHash signs the 32-byte digest with CKM_ECDSA, and returns the 65-byte signature [R || S || V] with low S
+func (s *Signer) SignHash(digest []byte) ([]byte, error) {
+	if len(digest) != 32 {
+		return nil, errors.Errorf("wrong size for digest: got %d, want 32", len(digest))
+	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if s.ctx == nil {
+		return nil, errors.New("signer is closed")
+	}
+	if err := s.ctx.SignInit(s.session, []*pkcs11.Mechanism{pkcs11.NewMechanism(pkcs11.CKM_ECDSA, nil)}, s.key); err != nil {
+		return nil, errors.Wrap(err, "failed to sign")
+	}
+	// the signature of CKM_ECDSA is r || s
+	rs, err := s.ctx.Sign(s.session, digest)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to sign")
+	}
+	if len(rs) != 64 {
+		return nil, errors.Errorf("wrong size for signature: got %d, want 64", len(rs))
+	}
+	return account.ToRecoverableSignature(new(big.Int).SetBytes(rs[:32]), new(big.Int).SetBytes(rs[32:]), digest, s.publicKey)
+}
+
+// Close logs out the token and closes the session
+func (s *Signer) Close() error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if s.ctx == nil {
+		return nil
+	}
+	err := closeSession(s.module, s.ctx, s.session)
+	s.ctx = nil
+	return err
+}
+
+// GenerateKey generates a secp256k1 key pair of the key label in the token. The private key is sensitive and not
+// extractable.
+func GenerateKey(cfg Config) (address.Address, error) {
+	ctx, session, err := open(cfg)
+	if err != nil {
+		return nil, err
+	}
+	defer closeSession(cfg.Module, ctx, session)
+	if _, err := findObject(ctx, session, pkcs11.CKO_PRIVATE_KEY, cfg.KeyLabel); err == nil {
+		return nil, errors.Errorf("key %s already exists", cfg.KeyLabel)
+	}
+	params, err := asn1.Marshal(_oidSecp256k1)
+	if err != nil {
+		return nil, err
+	}
+	if _, _, err := ctx.GenerateKeyPair(session,
+		[]*pkcs11.Mechanism{pkcs11.NewMechanism(pkcs11.CKM_EC_KEY_PAIR_GEN, nil)},
+		[]*pkcs11.Attribute{
+			pkcs11.NewAttribute(pkcs11.CKA_TOKEN, true),
+			pkcs11.NewAttribute(pkcs11.CKA_LABEL, cfg.KeyLabel),
+			pkcs11.NewAttribute(pkcs11.CKA_EC_PARAMS, params),
+			pkcs11.NewAttribute(pkcs11.CKA_VERIFY, true),
+		},
+		[]*pkcs11.Attribute{
+			pkcs11.NewAttribute(pkcs11.CKA_TOKEN, true),
+			pkcs11.NewAttribute(pkcs11.CKA_LABEL, cfg.KeyLabel),
+			pkcs11.NewAttribute(pkcs11.CKA_PRIVATE, true),
+			pkcs11.NewAttribute(pkcs11.CKA_SENSITIVE, true),
+			pkcs11.NewAttribute(pkcs11.CKA_EXTRACTABLE, false),
+			pkcs11.NewAttribute(pkcs11.CKA_SIGN, true),
+		},
+	); err != nil {
+		return nil, errors.Wrap(err, "failed to generate key")
+	}
+	s, err := newSigner(ctx, session, cfg.KeyLabel)
+	if err != nil {
+		return nil, err
+	}
+	return s.address, nil
+}
+
+// open loads the module, and logs in the token of the label
+func open(cfg Config) (*pkcs11.Ctx, pkcs11.SessionHandle, error) {
+	ctx, err := loadModule(cfg.Module)
+	if err != nil {
+		return nil, 0, err
+	}
+	session, err := openSession(ctx, cfg)
+	if err != nil {
+		unloadModule(cfg.Module)
+		return nil, 0, err
+	}
+	return ctx, session, nil
+}
+
+func loadModule(path string) (*pkcs11.Ctx, error) {
+	_modulesMutex.Lock()
+	defer _modulesMutex.Unlock()
+	if m, ok := _modules[path]; ok {
+		m.refs++
+		return m.ctx, nil
+	}
+	ctx := pkcs11.New(path)
+	if ctx == nil {
+		return nil, errors.Errorf("failed to load PKCS#11 module %s", path)
+	}
+	if err := ctx.Initialize(); err != nil {
+		ctx.Destroy()
+		return nil, errors.Wrap(err, "failed to initialize PKCS#11 module")
+	}
+	_modules[path] = &module{ctx: ctx, refs: 1}
+	return ctx, nil
+}
+
+func unloadModule(path string) {
+	_modulesMutex.Lock()
+	defer _modulesMutex.Unlock()
+	m, ok := _modules[path]
+	if !ok {
+		return
+	}
+	if m.refs--; m.refs == 0 {
+		m.ctx.Finalize()
+		m.ctx.Destroy()
+		delete(_modules, path)
+	}
+}
+
+func openSession(ctx *pkcs11.Ctx, cfg Config) (pkcs11.SessionHandle, error) {
+	slots, err := ctx.GetSlotList(true)
+	if err != nil {
+		return 0, err
+	}
+	for _, slot := range slots {
+		info, err := ctx.GetTokenInfo(slot)
+		if err != nil || info.Label != cfg.TokenLabel {
+			continue
+		}
+		session, err := ctx.OpenSession(slot, pkcs11.CKF_SERIAL_SESSION|pkcs11.CKF_RW_SESSION)
+		if err != nil {
+			return 0, errors.Wrapf(err, "failed to open session of token %s", cfg.TokenLabel)
+		}
+		// the login state is shared by the sessions of the token
+		if err := ctx.Login(session, pkcs11.CKU_USER, cfg.PIN); err != nil &&
+			!errors.Is(err, pkcs11.Error(pkcs11.CKR_USER_ALREADY_LOGGED_IN)) {
+			ctx.CloseSession(session)
+			return 0, errors.Wrapf(err, "failed to log in token %s", cfg.TokenLabel)
+		}
+		return session, nil
+	}
+	return 0, errors.Errorf("token %s not found", cfg.TokenLabel)
+}
+
+// closeSession closes the session, and the token is logged out when its last session is closed
+func closeSession(path string, ctx *pkcs11.Ctx, session pkcs11.SessionHandle) error {
+	err := ctx.CloseSession(session)
+	unloadModule(path)
+	return err
+}
+
+func findObject(ctx *pkcs11.Ctx, session pkcs11.SessionHandle, class uint, label string) (pkcs11.ObjectHandle, error) {
+	if err := ctx.FindObjectsInit(session, []*pkcs11.Attribute{
+		pkcs11.NewAttribute(pkcs11.CKA_CLASS, class),
+		pkcs11.NewAttribute(pkcs11.CKA_KEY_TYPE, pkcs11.CKK_EC),
+		pkcs11.NewAttribute(pkcs11.CKA_LABEL, label),
+	}); err != nil {
+		return 0, err
+	}
+	objs, _, err := ctx.FindObjects(session, 2)
+	ctx.FindObjectsFinal(session)
+	switch {
+	case err != nil:
+		return 0, err
+	case len(objs) == 0:
+		return 0, errors.Errorf("key %s not found", label)
+	case len(objs) > 1:
+		return 0, errors.Errorf("more than one key of label %s", label)
+	}
+	return objs[0], nil
+}
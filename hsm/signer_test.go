@@ -0,0 +1,166 @@
+// Copyright (c) 2020 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package hsm
+
+import (
+	"context"
+	"encoding/hex"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/iotexproject/go-pkgs/crypto"
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/iotexproject/iotex-proto/golang/iotexapi"
+	"github.com/iotexproject/iotex-proto/golang/iotexapi/mock_iotexapi"
+	"github.com/miekg/pkcs11"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+
+	"github.com/iotexproject/iotex-antenna-go/v2/account"
+	"github.com/iotexproject/iotex-antenna-go/v2/iotex"
+)
+
+// newSendAPI returns the API accepting the actions of 31337 signed by the signer
+func newSendAPI(t *testing.T, s account.Signer, times int) iotexapi.APIServiceClient {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+	api := mock_iotexapi.NewMockAPIServiceClient(ctrl)
+	api.EXPECT().SendAction(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, in *iotexapi.SendActionRequest, _ ...grpc.CallOption) (*iotexapi.SendActionResponse, error) {
+			act := in.GetAction()
+			require.NoError(t, iotex.VerifyAction(act, iotex.EVMNetworkID(31337)))
+			pk, err := crypto.BytesToPublicKey(act.GetSenderPubKey())
+			require.NoError(t, err)
+			require.Equal(t, s.Address().String(), pk.Address().String())
+			h, err := iotex.ActionHash(act, iotex.EVMNetworkID(31337))
+			require.NoError(t, err)
+			return &iotexapi.SendActionResponse{ActionHash: hex.EncodeToString(h[:])}, nil
+		}).Times(times)
+	return api
+}
+
+// softHSMModule returns the path of the SoftHSM2 library, set by SOFTHSM2_MODULE or installed by the softhsm2
+// package of Debian, Ubuntu or Fedora
+func softHSMModule() string {
+	if m := os.Getenv("SOFTHSM2_MODULE"); m != "" {
+		return m
+	}
+	for _, m := range []string{
+		"/usr/lib/softhsm/libsofthsm2.so",
+		"/usr/lib/x86_64-linux-gnu/softhsm/libsofthsm2.so",
+		"/usr/lib64/pkcs11/libsofthsm2.so",
+		"/usr/local/lib/softhsm/libsofthsm2.so",
+	} {
+		if _, err := os.Stat(m); err == nil {
+			return m
+		}
+	}
+	return ""
+}
+
+// newSoftHSMToken initializes a SoftHSM2 token in a temporary directory
+func newSoftHSMToken(t *testing.T) Config {
+	module := softHSMModule()
+	if module == "" {
+		t.Skip("SoftHSM2 is not installed, set SOFTHSM2_MODULE to the path of libsofthsm2.so")
+	}
+	require := require.New(t)
+	dir := t.TempDir()
+	require.NoError(os.Mkdir(filepath.Join(dir, "tokens"), 0700))
+	conf := filepath.Join(dir, "softhsm2.conf")
+	require.NoError(os.WriteFile(conf, []byte("directories.tokendir = "+filepath.Join(dir, "tokens")+"\n"), 0600))
+	t.Setenv("SOFTHSM2_CONF", conf)
+
+	cfg := Config{
+		Module:     module,
+		TokenLabel: "iotex",
+		PIN:        "1234",
+		KeyLabel:   "owner",
+	}
+	ctx := pkcs11.New(module)
+	require.NotNil(ctx)
+	require.NoError(ctx.Initialize())
+	defer ctx.Destroy()
+	defer ctx.Finalize()
+	slots, err := ctx.GetSlotList(false)
+	require.NoError(err)
+	require.NoError(ctx.InitToken(slots[0], "so-pin", cfg.TokenLabel))
+	// the token is moved to a new slot once initialized
+	slots, err = ctx.GetSlotList(true)
+	require.NoError(err)
+	for _, slot := range slots {
+		info, err := ctx.GetTokenInfo(slot)
+		require.NoError(err)
+		if info.Label != cfg.TokenLabel {
+			continue
+		}
+		session, err := ctx.OpenSession(slot, pkcs11.CKF_SERIAL_SESSION|pkcs11.CKF_RW_SESSION)
+		require.NoError(err)
+		require.NoError(ctx.Login(session, pkcs11.CKU_SO, "so-pin"))
+		require.NoError(ctx.InitPIN(session, cfg.PIN))
+		require.NoError(ctx.Logout(session))
+		require.NoError(ctx.CloseSession(session))
+		return cfg
+	}
+	t.Fatal("token is not initialized")
+	return cfg
+}
+
+func TestSigner(t *testing.T) {
+	require := require.New(t)
+	cfg := newSoftHSMToken(t)
+
+	_, err := NewSigner(cfg)
+	require.Contains(err.Error(), "key owner not found")
+	addr, err := GenerateKey(cfg)
+	require.NoError(err)
+	_, err = GenerateKey(cfg)
+	require.Error(err)
+	bad := cfg
+	bad.PIN = "wrong"
+	_, err = NewSigner(bad)
+	require.Error(err)
+
+	s, err := NewSigner(cfg)
+	require.NoError(err)
+	defer s.Close()
+	require.Equal(addr.String(), s.Address().String())
+
+	// digests and messages are signed concurrently
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			h := hash.Hash256b([]byte("hello"))
+			sig, err := account.SignHash(s, h[:])
+			require.NoError(err)
+			require.True(s.PublicKey().Verify(h[:], sig))
+			sig, err = account.SignMessage(s, []byte("hello"))
+			require.NoError(err)
+			mh := account.HashMessage([]byte("hello"))
+			recovered, err := account.RecoverAddress(mh[:], sig)
+			require.NoError(err)
+			require.Equal(addr.String(), recovered.String())
+		}()
+	}
+	wg.Wait()
+
+	// actions are signed by the token
+	to, err := account.NewAccount()
+	require.NoError(err)
+	h, err := iotex.NewSignerClient(newSendAPI(t, s, 1), 31337, s).Transfer(to.Address(), big.NewInt(1000)).
+		SetNonce(1).SetGasLimit(10000).SetGasPrice(big.NewInt(1e12)).Call(context.Background())
+	require.NoError(err)
+	require.NoError(s.Close())
+	_, err = s.SignHash(h[:])
+	require.Error(err)
+}
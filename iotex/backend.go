@@ -0,0 +1,423 @@
+// Copyright (c) 2020 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package iotex
+
+import (
+	"context"
+	"encoding/hex"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+	"github.com/iotexproject/iotex-address/address"
+	"github.com/iotexproject/iotex-proto/golang/iotexapi"
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/iotexproject/iotex-antenna-go/v2/account"
+	"github.com/iotexproject/iotex-antenna-go/v2/errcodes"
+)
+
+// ContractBackend is a go-ethereum bind.ContractBackend served by an IoTeX API endpoint, so that
+// abigen-generated bindings can be used against IoTeX.
+type ContractBackend interface {
+	bind.ContractBackend
+	bind.DeployBackend
+}
+
+type contractBackend struct {
+	api     iotexapi.APIServiceClient
+	chainID uint32
+}
+
+var _ ContractBackend = (*contractBackend)(nil)
+
+// NewContractBackend creates a ContractBackend on top of the client's API, for the given IoTeX chain ID
+// (1 for mainnet, 2 for testnet).
+func NewContractBackend(c ReadOnlyClient, chainID uint32) ContractBackend {
+	return &contractBackend{
+		api:     c.API(),
+		chainID: chainID,
+	}
+}
+
+// NewTransactOpts creates a bind.TransactOpts which signs transactions with the authed client's account.
+func NewTransactOpts(ctx context.Context, c AuthedClient) (*bind.TransactOpts, error) {
+	if c.ChainID() == 0 {
+		return nil, errcodes.New("0 is not a valid chain ID (use 1 for mainnet, 2 for testnet)", errcodes.InvalidParam)
+	}
+	acc, err := account.AsSigner(c.Account())
+	if err != nil {
+		return nil, err
+	}
+	signer := types.NewEIP155Signer(big.NewInt(int64(EVMNetworkID(c.ChainID()))))
+	from := toEthAddress(acc.Address())
+	return &bind.TransactOpts{
+		From: from,
+		Signer: func(addr common.Address, tx *types.Transaction) (*types.Transaction, error) {
+			if addr != from {
+				return nil, bind.ErrNotAuthorized
+			}
+			h := signer.Hash(tx)
+			sig, err := account.SignHash(acc, h[:])
+			if err != nil {
+				return nil, err
+			}
+			return tx.WithSignature(signer, sig)
+		},
+		Context: ctx,
+	}, nil
+}
+
+// CodeAt returns the code of the given account
+func (b *contractBackend) CodeAt(ctx context.Context, contract common.Address, blockNumber *big.Int) ([]byte, error) {
+	meta, err := b.accountMeta(ctx, contract)
+	if err != nil {
+		return nil, err
+	}
+	return meta.GetContractByteCode(), nil
+}
+
+// CallContract executes a read-only contract call
+func (b *contractBackend) CallContract(ctx context.Context, call ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	request := &iotexapi.ReadContractRequest{
+		Execution:     toExecution(call.To, call.Value, call.Data),
+		CallerAddress: address.ZeroAddress,
+		GasLimit:      call.Gas,
+	}
+	if call.From != (common.Address{}) {
+		from, err := fromEthAddress(call.From)
+		if err != nil {
+			return nil, errcodes.NewError(err, errcodes.InvalidParam)
+		}
+		request.CallerAddress = from.String()
+	}
+	if call.GasPrice != nil {
+		request.GasPrice = call.GasPrice.String()
+	}
+	response, err := b.api.ReadContract(ctx, request)
+	if err != nil {
+		return nil, errcodes.NewError(err, errcodes.RPCError)
+	}
+	decoded, err := hex.DecodeString(response.GetData())
+	if err != nil {
+		return nil, errcodes.NewError(err, errcodes.BadResponse)
+	}
+	return decoded, nil
+}
+
+// HeaderByNumber returns the header of the given height, or the latest header if number is nil
+func (b *contractBackend) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
+	var height uint64
+	if number == nil {
+		response, err := b.api.GetChainMeta(ctx, &iotexapi.GetChainMetaRequest{})
+		if err != nil {
+			return nil, errcodes.NewError(err, errcodes.RPCError)
+		}
+		height = response.GetChainMeta().GetHeight()
+	} else {
+		height = number.Uint64()
+	}
+	response, err := b.api.GetBlockMetas(ctx, &iotexapi.GetBlockMetasRequest{
+		Lookup: &iotexapi.GetBlockMetasRequest_ByIndex{
+			ByIndex: &iotexapi.GetBlockMetasByIndexRequest{Start: height, Count: 1},
+		},
+	})
+	if err != nil {
+		return nil, errcodes.NewError(err, errcodes.RPCError)
+	}
+	if len(response.GetBlkMetas()) == 0 {
+		return nil, ethereum.NotFound
+	}
+	meta := response.GetBlkMetas()[0]
+	// IoTeX has no base fee, a nil BaseFee makes bind fall back to legacy transactions
+	return &types.Header{
+		ParentHash: common.HexToHash(meta.GetPreviousBlockHash()),
+		Number:     new(big.Int).SetUint64(meta.GetHeight()),
+		GasLimit:   meta.GetGasLimit(),
+		GasUsed:    meta.GetGasUsed(),
+		Time:       uint64(meta.GetTimestamp().GetSeconds()),
+	}, nil
+}
+
+// PendingCodeAt returns the code of the given account
+func (b *contractBackend) PendingCodeAt(ctx context.Context, account common.Address) ([]byte, error) {
+	return b.CodeAt(ctx, account, nil)
+}
+
+// PendingNonceAt returns the pending nonce of the given account
+func (b *contractBackend) PendingNonceAt(ctx context.Context, account common.Address) (uint64, error) {
+	meta, err := b.accountMeta(ctx, account)
+	if err != nil {
+		return 0, err
+	}
+	return meta.GetPendingNonce(), nil
+}
+
+// SuggestGasPrice returns the gas price suggested by the node
+func (b *contractBackend) SuggestGasPrice(ctx context.Context) (*big.Int, error) {
+	response, err := b.api.SuggestGasPrice(ctx, &iotexapi.SuggestGasPriceRequest{})
+	if err != nil {
+		return nil, errcodes.NewError(err, errcodes.RPCError)
+	}
+	return new(big.Int).SetUint64(response.GetGasPrice()), nil
+}
+
+// SuggestGasTipCap returns the suggested gas price, IoTeX does not support dynamic fee transactions
+func (b *contractBackend) SuggestGasTipCap(ctx context.Context) (*big.Int, error) {
+	return b.SuggestGasPrice(ctx)
+}
+
+// EstimateGas estimates the gas needed to execute the call
+func (b *contractBackend) EstimateGas(ctx context.Context, call ethereum.CallMsg) (uint64, error) {
+	request := &iotexapi.EstimateActionGasConsumptionRequest{}
+	if call.From != (common.Address{}) {
+		from, err := fromEthAddress(call.From)
+		if err != nil {
+			return 0, errcodes.NewError(err, errcodes.InvalidParam)
+		}
+		request.CallerAddress = from.String()
+	}
+	exec := toExecution(call.To, call.Value, call.Data)
+	if call.To != nil && len(call.Data) == 0 {
+		request.Action = &iotexapi.EstimateActionGasConsumptionRequest_Transfer{
+			Transfer: &iotextypes.Transfer{
+				Amount:    exec.Amount,
+				Recipient: exec.Contract,
+			},
+		}
+	} else {
+		request.Action = &iotexapi.EstimateActionGasConsumptionRequest_Execution{Execution: exec}
+	}
+	response, err := b.api.EstimateActionGasConsumption(ctx, request)
+	if err != nil {
+		return 0, errcodes.NewError(err, errcodes.RPCError)
+	}
+	return response.GetGas(), nil
+}
+
+// SendTransaction sends a signed transaction as an Ethereum RLP-encoded action
+func (b *contractBackend) SendTransaction(ctx context.Context, tx *types.Transaction) error {
+	act, err := EthTxToAction(tx, b.chainID)
+	if err != nil {
+		return errcodes.NewError(err, errcodes.InvalidParam)
+	}
+	if _, err := b.api.SendAction(ctx, &iotexapi.SendActionRequest{Action: act}); err != nil {
+		return errcodes.NewError(err, errcodes.RPCError)
+	}
+	return nil
+}
+
+// TransactionReceipt returns the receipt of the given transaction
+func (b *contractBackend) TransactionReceipt(ctx context.Context, txHash common.Hash) (*types.Receipt, error) {
+	response, err := b.api.GetReceiptByAction(ctx, &iotexapi.GetReceiptByActionRequest{
+		ActionHash: hex.EncodeToString(txHash[:]),
+	})
+	if status.Code(err) == codes.NotFound {
+		// pending, as go-ethereum callers such as bind.WaitMined expect
+		return nil, ethereum.NotFound
+	}
+	if err != nil {
+		return nil, errcodes.NewError(err, errcodes.RPCError)
+	}
+	info := response.GetReceiptInfo()
+	r := info.GetReceipt()
+	blkHash := common.HexToHash(info.GetBlkHash())
+	receipt := &types.Receipt{
+		Type:             types.LegacyTxType,
+		Status:           types.ReceiptStatusFailed,
+		GasUsed:          r.GetGasConsumed(),
+		TxHash:           common.BytesToHash(r.GetActHash()),
+		BlockHash:        blkHash,
+		BlockNumber:      new(big.Int).SetUint64(r.GetBlkHeight()),
+		TransactionIndex: uint(r.GetTxIndex()),
+	}
+	if r.GetStatus() == uint64(iotextypes.ReceiptStatus_Success) {
+		receipt.Status = types.ReceiptStatusSuccessful
+	}
+	if r.GetContractAddress() != "" {
+		addr, err := address.FromString(r.GetContractAddress())
+		if err != nil {
+			return nil, errcodes.NewError(err, errcodes.BadResponse)
+		}
+		receipt.ContractAddress = toEthAddress(addr)
+	}
+	for _, l := range r.GetLogs() {
+		log, err := toEthLog(l)
+		if err != nil {
+			return nil, errcodes.NewError(err, errcodes.BadResponse)
+		}
+		log.BlockHash = blkHash
+		receipt.Logs = append(receipt.Logs, log)
+	}
+	receipt.Bloom = types.CreateBloom(types.Receipts{receipt})
+	return receipt, nil
+}
+
+// FilterLogs executes a log filter query
+func (b *contractBackend) FilterLogs(ctx context.Context, q ethereum.FilterQuery) ([]types.Log, error) {
+	request := &iotexapi.GetLogsRequest{Filter: toLogsFilter(q)}
+	if q.BlockHash != nil {
+		request.Lookup = &iotexapi.GetLogsRequest_ByBlock{
+			ByBlock: &iotexapi.GetLogsByBlock{BlockHash: q.BlockHash.Bytes()},
+		}
+	} else {
+		from, to := uint64(1), uint64(0)
+		if q.FromBlock != nil && q.FromBlock.Sign() > 0 {
+			from = q.FromBlock.Uint64()
+		}
+		if q.ToBlock != nil {
+			to = q.ToBlock.Uint64()
+		} else {
+			response, err := b.api.GetChainMeta(ctx, &iotexapi.GetChainMetaRequest{})
+			if err != nil {
+				return nil, errcodes.NewError(err, errcodes.RPCError)
+			}
+			to = response.GetChainMeta().GetHeight()
+		}
+		request.Lookup = &iotexapi.GetLogsRequest_ByRange{
+			ByRange: &iotexapi.GetLogsByRange{FromBlock: from, ToBlock: to},
+		}
+	}
+	response, err := b.api.GetLogs(ctx, request)
+	if err != nil {
+		return nil, errcodes.NewError(err, errcodes.RPCError)
+	}
+	logs := make([]types.Log, 0, len(response.GetLogs()))
+	for _, l := range response.GetLogs() {
+		log, err := toEthLog(l)
+		if err != nil {
+			return nil, errcodes.NewError(err, errcodes.BadResponse)
+		}
+		logs = append(logs, *log)
+	}
+	return logs, nil
+}
+
+// SubscribeFilterLogs subscribes to the logs of new blocks matching the query
+func (b *contractBackend) SubscribeFilterLogs(ctx context.Context, q ethereum.FilterQuery, ch chan<- types.Log) (ethereum.Subscription, error) {
+	streamCtx, cancel := context.WithCancel(ctx)
+	stream, err := b.api.StreamLogs(streamCtx, &iotexapi.StreamLogsRequest{Filter: toLogsFilter(q)})
+	if err != nil {
+		cancel()
+		return nil, errcodes.NewError(err, errcodes.RPCError)
+	}
+	logs := make(chan *iotextypes.Log)
+	errs := make(chan error, 1)
+	go func() {
+		for {
+			response, err := stream.Recv()
+			if err != nil {
+				errs <- err
+				return
+			}
+			select {
+			case logs <- response.GetLog():
+			case <-streamCtx.Done():
+				return
+			}
+		}
+	}()
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer cancel()
+		for {
+			select {
+			case l := <-logs:
+				log, err := toEthLog(l)
+				if err != nil {
+					return errcodes.NewError(err, errcodes.BadResponse)
+				}
+				select {
+				case ch <- *log:
+				case <-quit:
+					return nil
+				}
+			case err := <-errs:
+				return errcodes.NewError(err, errcodes.RPCError)
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+func (b *contractBackend) accountMeta(ctx context.Context, addr common.Address) (*iotextypes.AccountMeta, error) {
+	ioAddr, err := fromEthAddress(addr)
+	if err != nil {
+		return nil, errcodes.NewError(err, errcodes.InvalidParam)
+	}
+	response, err := b.api.GetAccount(ctx, &iotexapi.GetAccountRequest{Address: ioAddr.String()})
+	if err != nil {
+		return nil, errcodes.NewError(err, errcodes.RPCError)
+	}
+	return response.GetAccountMeta(), nil
+}
+
+func toExecution(to *common.Address, value *big.Int, data []byte) *iotextypes.Execution {
+	exec := &iotextypes.Execution{
+		Amount: "0",
+		Data:   data,
+	}
+	if to != nil {
+		// a 20-byte address always converts
+		addr, _ := fromEthAddress(*to)
+		exec.Contract = addr.String()
+	}
+	if value != nil {
+		exec.Amount = value.String()
+	}
+	return exec
+}
+
+func toLogsFilter(q ethereum.FilterQuery) *iotexapi.LogsFilter {
+	filter := &iotexapi.LogsFilter{}
+	for _, a := range q.Addresses {
+		addr, _ := fromEthAddress(a)
+		filter.Address = append(filter.Address, addr.String())
+	}
+	for _, topics := range q.Topics {
+		t := &iotexapi.Topics{}
+		for _, topic := range topics {
+			t.Topic = append(t.Topic, topic.Bytes())
+		}
+		filter.Topics = append(filter.Topics, t)
+	}
+	return filter
+}
+
+func toEthLog(l *iotextypes.Log) (*types.Log, error) {
+	addr, err := address.FromString(l.GetContractAddress())
+	if err != nil {
+		return nil, err
+	}
+	topics := make([]common.Hash, len(l.GetTopics()))
+	for i, t := range l.GetTopics() {
+		topics[i] = common.BytesToHash(t)
+	}
+	return &types.Log{
+		Address:     toEthAddress(addr),
+		Topics:      topics,
+		Data:        l.GetData(),
+		BlockNumber: l.GetBlkHeight(),
+		TxHash:      common.BytesToHash(l.GetActHash()),
+		TxIndex:     uint(l.GetTxIndex()),
+		BlockHash:   common.BytesToHash(l.GetBlkHash()),
+		Index:       uint(l.GetIndex()),
+	}, nil
+}
+
+func toEthAddress(addr address.Address) common.Address {
+	return common.BytesToAddress(addr.Bytes())
+}
+
+func fromEthAddress(addr common.Address) (address.Address, error) {
+	return address.FromBytes(addr.Bytes())
+}
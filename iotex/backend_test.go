@@ -0,0 +1,143 @@
+// Copyright (c) 2020 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package iotex
+
+import (
+	"context"
+	"encoding/hex"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/golang/mock/gomock"
+	"github.com/iotexproject/iotex-address/address"
+	"github.com/iotexproject/iotex-proto/golang/iotexapi"
+	"github.com/iotexproject/iotex-proto/golang/iotexapi/mock_iotexapi"
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/iotexproject/iotex-antenna-go/v2/account"
+)
+
+const _setGetABI = `[{"constant":false,"inputs":[{"name":"x","type":"uint256"}],"name":"set","outputs":[],"payable":false,"stateMutability":"nonpayable","type":"function"},{"constant":true,"inputs":[],"name":"get","outputs":[{"name":"","type":"uint256"}],"payable":false,"stateMutability":"view","type":"function"}]`
+
+func TestContractBackend_CallAndTransact(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	acc, err := account.HexStringToAccount(_accountPrivateKey)
+	require.NoError(err)
+	contract, err := address.FromString("io17sn486alutrnzlrdz9vv44g7qyc38hygf7s6h0")
+	require.NoError(err)
+	parsed, err := abi.JSON(strings.NewReader(_setGetABI))
+	require.NoError(err)
+
+	api := mock_iotexapi.NewMockAPIServiceClient(ctrl)
+	api.EXPECT().ReadContract(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, in *iotexapi.ReadContractRequest, _ ...interface{}) (*iotexapi.ReadContractResponse, error) {
+			require.Equal(contract.String(), in.GetExecution().GetContract())
+			require.Equal(acc.Address().String(), in.GetCallerAddress())
+			return &iotexapi.ReadContractResponse{
+				Data: "000000000000000000000000000000000000000000000000000000000000002a",
+			}, nil
+		})
+	api.EXPECT().GetAccount(gomock.Any(), gomock.Any()).Return(&iotexapi.GetAccountResponse{
+		AccountMeta: &iotextypes.AccountMeta{PendingNonce: 7},
+	}, nil)
+	api.EXPECT().GetChainMeta(gomock.Any(), gomock.Any()).Return(&iotexapi.GetChainMetaResponse{
+		ChainMeta: &iotextypes.ChainMeta{Height: 100},
+	}, nil)
+	api.EXPECT().GetBlockMetas(gomock.Any(), gomock.Any()).Return(&iotexapi.GetBlockMetasResponse{
+		BlkMetas: []*iotextypes.BlockMeta{{Height: 100}},
+	}, nil)
+	api.EXPECT().SuggestGasPrice(gomock.Any(), gomock.Any()).Return(&iotexapi.SuggestGasPriceResponse{GasPrice: 1000000000000}, nil)
+	var sent *iotextypes.Action
+	api.EXPECT().SendAction(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, in *iotexapi.SendActionRequest, _ ...interface{}) (*iotexapi.SendActionResponse, error) {
+			sent = in.GetAction()
+			return &iotexapi.SendActionResponse{}, nil
+		})
+
+	c := NewAuthedClient(api, 2, acc)
+	backend := NewContractBackend(c, c.ChainID())
+	bound := bind.NewBoundContract(toEthAddress(contract), parsed, backend, backend, backend)
+
+	var out []interface{}
+	require.NoError(bound.Call(&bind.CallOpts{From: toEthAddress(acc.Address())}, &out, "get"))
+	require.Equal(big.NewInt(42), out[0])
+
+	opts, err := NewTransactOpts(context.Background(), c)
+	require.NoError(err)
+	opts.GasLimit = 100000
+	tx, err := bound.Transact(opts, "set", big.NewInt(8))
+	require.NoError(err)
+
+	require.NotNil(sent)
+	require.Equal(iotextypes.Encoding_ETHEREUM_RLP, sent.GetEncoding())
+	require.Equal(acc.PublicKey().Bytes(), sent.GetSenderPubKey())
+	require.EqualValues(7, sent.GetCore().GetNonce())
+	require.EqualValues(2, sent.GetCore().GetChainID())
+	require.Equal(contract.String(), sent.GetCore().GetExecution().GetContract())
+	h, err := ActionHash(sent, TestnetEVMNetworkID)
+	require.NoError(err)
+	require.Equal(tx.Hash().Bytes(), h[:])
+
+	// the receipt of a pending transaction is not found
+	api.EXPECT().GetReceiptByAction(gomock.Any(), gomock.Any()).Return(nil, status.Error(codes.NotFound, "receipt not found"))
+	_, err = backend.TransactionReceipt(context.Background(), tx.Hash())
+	require.Equal(ethereum.NotFound, err)
+}
+
+func TestContractBackend_FilterLogs(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	contract, err := address.FromString("io17sn486alutrnzlrdz9vv44g7qyc38hygf7s6h0")
+	require.NoError(err)
+	topic := common.HexToHash("ddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef")
+	actHash, _ := hex.DecodeString("16fbdac39a19f433b32e457eba9c64c48d9fafcdffaddb11a0b7d264c0cf1418")
+
+	api := mock_iotexapi.NewMockAPIServiceClient(ctrl)
+	api.EXPECT().GetLogs(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, in *iotexapi.GetLogsRequest, _ ...interface{}) (*iotexapi.GetLogsResponse, error) {
+			require.Equal([]string{contract.String()}, in.GetFilter().GetAddress())
+			require.Equal([][]byte{topic.Bytes()}, in.GetFilter().GetTopics()[0].GetTopic())
+			require.EqualValues(10, in.GetByRange().GetFromBlock())
+			require.EqualValues(20, in.GetByRange().GetToBlock())
+			return &iotexapi.GetLogsResponse{Logs: []*iotextypes.Log{{
+				ContractAddress: contract.String(),
+				Topics:          [][]byte{topic.Bytes()},
+				Data:            []byte{1},
+				BlkHeight:       15,
+				ActHash:         actHash,
+				Index:           3,
+			}}}, nil
+		})
+
+	backend := NewContractBackend(NewReadOnlyClient(api), 2)
+	logs, err := backend.FilterLogs(context.Background(), ethereum.FilterQuery{
+		FromBlock: big.NewInt(10),
+		ToBlock:   big.NewInt(20),
+		Addresses: []common.Address{toEthAddress(contract)},
+		Topics:    [][]common.Hash{{topic}},
+	})
+	require.NoError(err)
+	require.Equal(1, len(logs))
+	require.Equal(toEthAddress(contract), logs[0].Address)
+	require.Equal([]common.Hash{topic}, logs[0].Topics)
+	require.EqualValues(15, logs[0].BlockNumber)
+	require.EqualValues(3, logs[0].Index)
+	require.Equal(common.BytesToHash(actHash), logs[0].TxHash)
+}
@@ -43,8 +43,22 @@ func (c *claimRewardCaller) SetNonce(n uint64) ClaimRewardCaller {
 }
 
 func (c *claimRewardCaller) Call(ctx context.Context, opts ...grpc.CallOption) (hash.Hash256, error) {
+	if err := c.setCore(); err != nil {
+		return hash.ZeroHash256, err
+	}
+	return c.sendActionCaller.Call(ctx, opts...)
+}
+
+func (c *claimRewardCaller) Build(ctx context.Context, opts ...grpc.CallOption) (*UnsignedAction, error) {
+	if err := c.setCore(); err != nil {
+		return nil, err
+	}
+	return c.sendActionCaller.Build(ctx, opts...)
+}
+
+func (c *claimRewardCaller) setCore() error {
 	if c.amount == nil {
-		return hash.ZeroHash256, errcodes.New("claim amount cannot be nil", errcodes.InvalidParam)
+		return errcodes.New("claim amount cannot be nil", errcodes.InvalidParam)
 	}
 
 	tx := iotextypes.ClaimFromRewardingFund{
@@ -55,5 +69,5 @@ func (c *claimRewardCaller) Call(ctx context.Context, opts ...grpc.CallOption) (
 		Version: ProtocolVersion,
 		Action:  &iotextypes.ActionCore_ClaimFromRewardingFund{ClaimFromRewardingFund: &tx},
 	}
-	return c.sendActionCaller.Call(ctx, opts...)
+	return nil
 }
@@ -9,11 +9,10 @@ package iotex
 import (
 	"context"
 	"encoding/hex"
+	"fmt"
 	"math/big"
-	"reflect"
 
 	"github.com/ethereum/go-ethereum/accounts/abi"
-	"github.com/ethereum/go-ethereum/common"
 	"github.com/iotexproject/go-pkgs/hash"
 	"github.com/iotexproject/iotex-address/address"
 	"github.com/iotexproject/iotex-proto/golang/iotexapi"
@@ -51,31 +50,48 @@ func (c *deployContractCaller) SetNonce(n uint64) DeployContractCaller {
 }
 
 func (c *deployContractCaller) Call(ctx context.Context, opts ...grpc.CallOption) (hash.Hash256, error) {
+	if err := c.setCore(); err != nil {
+		return hash.ZeroHash256, err
+	}
+	return c.sendActionCaller.Call(ctx, opts...)
+}
+
+func (c *deployContractCaller) Build(ctx context.Context, opts ...grpc.CallOption) (*UnsignedAction, error) {
+	if err := c.setCore(); err != nil {
+		return nil, err
+	}
+	return c.sendActionCaller.Build(ctx, opts...)
+}
+
+func (c *deployContractCaller) setCore() error {
 	if len(c.payload) == 0 {
-		return hash.ZeroHash256, errcodes.New("contract data can not empty", errcodes.InvalidParam)
+		return errcodes.New("contract data can not empty", errcodes.InvalidParam)
 	}
+	// keep c.payload as the bytecode, so the caller can be called again
+	data := c.payload[:len(c.payload):len(c.payload)]
 	if len(c.args) > 0 {
 		var err error
 		c.args, err = encodeArgument(c.abi.Constructor, c.args)
 		if err != nil {
-			return hash.ZeroHash256, errcodes.NewError(err, errcodes.InvalidParam)
+			return errcodes.NewError(err, errcodes.InvalidParam)
 		}
 		packed, err := c.abi.Pack("", c.args...)
 		if err != nil {
-			return hash.ZeroHash256, errcodes.New("failed to pack args", errcodes.InvalidParam)
+			return errcodes.New("failed to pack args", errcodes.InvalidParam)
 		}
-		c.payload = append(c.payload, packed...)
+		data = append(data, packed...)
 	}
 
 	exec := &iotextypes.Execution{
-		Data:   c.payload,
+		Data:   data,
 		Amount: "0",
 	}
 	c.core = &iotextypes.ActionCore{
 		Version: ProtocolVersion,
 		Action:  &iotextypes.ActionCore_Execution{Execution: exec},
 	}
-	return c.sendActionCaller.Call(ctx, opts...)
+	c.revertABI = c.abi
+	return nil
 }
 
 type contractArgs struct {
@@ -112,23 +128,37 @@ func (c *executeContractCaller) SetNonce(n uint64) ExecuteContractCaller {
 }
 
 func (c *executeContractCaller) Call(ctx context.Context, opts ...grpc.CallOption) (hash.Hash256, error) {
+	if err := c.setCore(); err != nil {
+		return hash.ZeroHash256, err
+	}
+	return c.sendActionCaller.Call(ctx, opts...)
+}
+
+func (c *executeContractCaller) Build(ctx context.Context, opts ...grpc.CallOption) (*UnsignedAction, error) {
+	if err := c.setCore(); err != nil {
+		return nil, err
+	}
+	return c.sendActionCaller.Build(ctx, opts...)
+}
+
+func (c *executeContractCaller) setCore() error {
 	if c.method == "" {
-		return hash.ZeroHash256, errcodes.New("contract address and method can not empty", errcodes.InvalidParam)
+		return errcodes.New("contract address and method can not empty", errcodes.InvalidParam)
 	}
 
 	method, exist := c.abi.Methods[c.method]
 	if !exist {
-		return hash.ZeroHash256, errcodes.New("method is not found", errcodes.InvalidParam)
+		return errcodes.New("method is not found", errcodes.InvalidParam)
 	}
 	var err error
 	c.args, err = encodeArgument(method, c.args)
 	if err != nil {
-		return hash.ZeroHash256, errcodes.NewError(err, errcodes.InvalidParam)
+		return errcodes.NewError(err, errcodes.InvalidParam)
 	}
 
 	c.payload, err = c.abi.Pack(c.method, c.args...)
 	if err != nil {
-		return hash.ZeroHash256, errcodes.NewError(err, errcodes.InvalidParam)
+		return errcodes.NewError(err, errcodes.InvalidParam)
 	}
 
 	exec := &iotextypes.Execution{
@@ -143,7 +173,8 @@ func (c *executeContractCaller) Call(ctx context.Context, opts ...grpc.CallOptio
 		Version: ProtocolVersion,
 		Action:  &iotextypes.ActionCore_Execution{Execution: exec},
 	}
-	return c.sendActionCaller.Call(ctx, opts...)
+	c.revertABI = c.abi
+	return nil
 }
 
 type readContractCaller struct {
@@ -187,6 +218,9 @@ func (c *readContractCaller) Call(ctx context.Context, opts ...grpc.CallOption)
 	if err != nil {
 		return Data{}, errcodes.NewError(err, errcodes.BadResponse)
 	}
+	if r := response.GetReceipt(); r != nil && r.GetStatus() != uint64(iotextypes.ReceiptStatus_Success) {
+		return Data{}, errcodes.NewError(DecodeRevert(c.abi, iotextypes.ReceiptStatus(r.GetStatus()), decoded), errcodes.ExecutionFailed)
+	}
 
 	return Data{
 		method: c.method,
@@ -201,29 +235,14 @@ func encodeArgument(method abi.Method, args []interface{}) ([]interface{}, error
 	}
 	newArgs := make([]interface{}, len(args))
 	for index, input := range method.Inputs {
-		switch input.Type.String() {
-		case "address":
-			var err error
-			newArgs[index], err = addressTypeAssert(args[index])
-			if err != nil {
-				return nil, errcodes.NewError(err, errcodes.InvalidParam)
-			}
-		case "address[]":
-			s := reflect.ValueOf(args[index])
-			if s.Kind() != reflect.Slice && s.Kind() != reflect.Array {
-				return nil, errcodes.New("fail because the type is non-slice, non-array", errcodes.InvalidParam)
-			}
-			newArr := make([]common.Address, s.Len())
-			for j := 0; j < s.Len(); j++ {
-				var err error
-				newArr[j], err = addressTypeAssert(s.Index(j).Interface())
-				if err != nil {
-					return nil, errcodes.NewError(err, errcodes.InvalidParam)
-				}
-			}
-			newArgs[index] = newArr
-		default:
-			newArgs[index] = args[index]
+		name := input.Name
+		if name == "" {
+			name = fmt.Sprintf("#%d", index)
+		}
+		var err error
+		newArgs[index], err = encodeValue(input.Type, args[index], name)
+		if err != nil {
+			return nil, errcodes.NewError(err, errcodes.InvalidParam)
 		}
 	}
 	return newArgs, nil
@@ -155,6 +155,21 @@ func (c *stakingCaller) SetPayload(pl []byte) SendActionCaller {
 
 //Call call sendActionCaller
 func (c *stakingCaller) Call(ctx context.Context, opts ...grpc.CallOption) (hash.Hash256, error) {
+	if err := c.setCore(); err != nil {
+		return hash.ZeroHash256, err
+	}
+	return c.sendActionCaller.Call(ctx, opts...)
+}
+
+// Build builds the unsigned action
+func (c *stakingCaller) Build(ctx context.Context, opts ...grpc.CallOption) (*UnsignedAction, error) {
+	if err := c.setCore(); err != nil {
+		return nil, err
+	}
+	return c.sendActionCaller.Build(ctx, opts...)
+}
+
+func (c *stakingCaller) setCore() error {
 	c.core = &iotextypes.ActionCore{
 		Version: ProtocolVersion,
 	}
@@ -203,7 +218,7 @@ func (c *stakingCaller) Call(ctx context.Context, opts ...grpc.CallOption) (hash
 	case *iotextypes.CandidateBasicInfo:
 		c.core.Action = &iotextypes.ActionCore_CandidateUpdate{CandidateUpdate: a}
 	default:
-		return hash.ZeroHash256, errcodes.New("not support action call", errcodes.InternalError)
+		return errcodes.New("not support action call", errcodes.InternalError)
 	}
-	return c.sendActionCaller.Call(ctx, opts...)
+	return nil
 }
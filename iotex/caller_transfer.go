@@ -45,8 +45,22 @@ func (c *transferCaller) SetNonce(n uint64) SendActionCaller {
 }
 
 func (c *transferCaller) Call(ctx context.Context, opts ...grpc.CallOption) (hash.Hash256, error) {
+	if err := c.setCore(); err != nil {
+		return hash.ZeroHash256, err
+	}
+	return c.sendActionCaller.Call(ctx, opts...)
+}
+
+func (c *transferCaller) Build(ctx context.Context, opts ...grpc.CallOption) (*UnsignedAction, error) {
+	if err := c.setCore(); err != nil {
+		return nil, err
+	}
+	return c.sendActionCaller.Build(ctx, opts...)
+}
+
+func (c *transferCaller) setCore() error {
 	if c.amount == nil {
-		return hash.ZeroHash256, errcodes.New("transfer amount cannot be nil", errcodes.InvalidParam)
+		return errcodes.New("transfer amount cannot be nil", errcodes.InvalidParam)
 	}
 
 	tx := iotextypes.Transfer{
@@ -58,5 +72,5 @@ func (c *transferCaller) Call(ctx context.Context, opts ...grpc.CallOption) (has
 		Version: ProtocolVersion,
 		Action:  &iotextypes.ActionCore_Transfer{Transfer: &tx},
 	}
-	return c.sendActionCaller.Call(ctx, opts...)
+	return nil
 }
@@ -10,7 +10,9 @@ import (
 	"context"
 	"encoding/hex"
 	"math/big"
+	"strings"
 
+	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/iotexproject/go-pkgs/hash"
 	"github.com/iotexproject/iotex-address/address"
@@ -26,14 +28,18 @@ import (
 const ProtocolVersion = 1
 
 type sendActionCaller struct {
-	account  account.Account
-	api      iotexapi.APIServiceClient
-	nonce    uint64
-	gasLimit uint64
-	gasPrice *big.Int
-	chainID  uint32
-	payload  []byte
-	core     *iotextypes.ActionCore
+	account      account.Account
+	api          iotexapi.APIServiceClient
+	nonceManager NonceManager
+	nonce        uint64
+	gasLimit     uint64
+	gasPrice     *big.Int
+	chainID      uint32
+	payload      []byte
+	encoding     iotextypes.Encoding
+	core         *iotextypes.ActionCore
+	// revertABI decodes the custom errors of the executions, nil for the other actions
+	revertABI *abi.ABI
 }
 
 //API returns api
@@ -68,23 +74,82 @@ func (c *sendActionCaller) Call(ctx context.Context, opts ...grpc.CallOption) (h
 	c.core.ChainID = c.chainID
 
 	if c.nonce == 0 {
+		if c.nonceManager != nil {
+			return c.callWithNonceManager(ctx, opts...)
+		}
 		res, err := c.api.GetAccount(ctx, &iotexapi.GetAccountRequest{Address: c.account.Address().String()}, opts...)
 		if err != nil {
 			return hash.ZeroHash256, errcodes.NewError(err, errcodes.RPCError)
 		}
 		c.nonce = res.GetAccountMeta().GetPendingNonce()
 	}
+	return c.call(ctx, opts...)
+}
+
+// callWithNonceManager reserves the nonce from the nonce manager, and gives it back if the action is rejected for sure.
+// If the nonce is taken on chain, the nonce manager resyncs from chain, and the action is retried once. On the other
+// errors of sending, the action may have been in the mempool, so the nonce is kept reserved.
+func (c *sendActionCaller) callWithNonceManager(ctx context.Context, opts ...grpc.CallOption) (hash.Hash256, error) {
+	addr := c.account.Address()
+	for retry := 0; ; retry++ {
+		nonce, err := c.nonceManager.Reserve(ctx, addr)
+		if err != nil {
+			return hash.ZeroHash256, errcodes.NewError(err, errcodes.RPCError)
+		}
+		c.nonce = nonce
+		sealed, err := c.seal(ctx, opts...)
+		if err != nil {
+			// not sent
+			c.nonce = 0
+			c.nonceManager.Release(addr, nonce)
+			return hash.ZeroHash256, err
+		}
+		h, err := c.send(ctx, sealed, opts...)
+		if err == nil {
+			return h, nil
+		}
+		c.nonce = 0
+		if e, ok := err.(errcodes.ErrorWithCode); ok && e.Code() == errcodes.BadResponse {
+			// the action has been sent
+			return hash.ZeroHash256, err
+		}
+		switch {
+		case isNonceTooLowError(err):
+			c.nonceManager.Reset(addr)
+			if retry > 0 {
+				return hash.ZeroHash256, err
+			}
+		case isRejectedError(err):
+			c.nonceManager.Release(addr, nonce)
+			return hash.ZeroHash256, err
+		default:
+			return hash.ZeroHash256, err
+		}
+	}
+}
+
+// call signs and sends the action with the nonce already set
+func (c *sendActionCaller) call(ctx context.Context, opts ...grpc.CallOption) (hash.Hash256, error) {
+	sealed, err := c.seal(ctx, opts...)
+	if err != nil {
+		return hash.ZeroHash256, err
+	}
+	return c.send(ctx, sealed, opts...)
+}
+
+// seal fills the gas and signs the action with the nonce already set
+func (c *sendActionCaller) seal(ctx context.Context, opts ...grpc.CallOption) (*iotextypes.Action, error) {
 	c.core.Nonce = c.nonce
 
 	if c.gasLimit == 0 {
-		sealed, err := sign(c.account, c.core)
+		sealed, err := signAction(c.account, c.core, c.encoding)
 		if err != nil {
-			return hash.ZeroHash256, errcodes.NewError(err, errcodes.InternalError)
+			return nil, errcodes.NewError(err, errcodes.InternalError)
 		}
 		request := &iotexapi.EstimateGasForActionRequest{Action: sealed}
 		response, err := c.api.EstimateGasForAction(ctx, request, opts...)
 		if err != nil {
-			return hash.ZeroHash256, errcodes.NewError(err, errcodes.RPCError)
+			return nil, estimateGasError(c.revertABI, err)
 		}
 		c.gasLimit = response.GetGas()
 	}
@@ -93,17 +158,21 @@ func (c *sendActionCaller) Call(ctx context.Context, opts ...grpc.CallOption) (h
 	if c.gasPrice == nil {
 		response, err := c.api.SuggestGasPrice(ctx, &iotexapi.SuggestGasPriceRequest{}, opts...)
 		if err != nil {
-			return hash.ZeroHash256, errcodes.NewError(err, errcodes.RPCError)
+			return nil, errcodes.NewError(err, errcodes.RPCError)
 		}
 		c.gasPrice = big.NewInt(0).SetUint64(response.GetGasPrice())
 	}
 	c.core.GasPrice = c.gasPrice.String()
 
-	sealed, err := sign(c.account, c.core)
+	sealed, err := signAction(c.account, c.core, c.encoding)
 	if err != nil {
-		return hash.ZeroHash256, errcodes.NewError(err, errcodes.InternalError)
+		return nil, errcodes.NewError(err, errcodes.InternalError)
 	}
+	return sealed, nil
+}
 
+// send sends the signed action
+func (c *sendActionCaller) send(ctx context.Context, sealed *iotextypes.Action, opts ...grpc.CallOption) (hash.Hash256, error) {
 	response, err := c.api.SendAction(ctx, &iotexapi.SendActionRequest{Action: sealed}, opts...)
 	if err != nil {
 		return hash.ZeroHash256, errcodes.NewError(err, errcodes.RPCError)
@@ -138,6 +207,12 @@ func (c *getLogsCaller) Call(ctx context.Context, opts ...grpc.CallOption) (*iot
 func addressTypeAssert(preVal interface{}) (common.Address, error) {
 	switch v := preVal.(type) {
 	case string:
+		if strings.HasPrefix(v, "0x") || strings.HasPrefix(v, "0X") {
+			if !common.IsHexAddress(v) {
+				return common.Address{}, errcodes.New("fail to convert string to ethAddress", errcodes.InvalidParam)
+			}
+			return common.HexToAddress(v), nil
+		}
 		ioAddress, err := address.FromString(v)
 		if err != nil {
 			return common.Address{}, errcodes.New("fail to convert string to ioAddress", errcodes.InvalidParam)
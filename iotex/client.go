@@ -7,91 +7,113 @@ import (
 	"github.com/iotexproject/go-pkgs/hash"
 	"github.com/iotexproject/iotex-address/address"
 	"github.com/iotexproject/iotex-proto/golang/iotexapi"
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
 
 	"github.com/iotexproject/iotex-antenna-go/v2/account"
 )
 
 type authedClient struct {
 	client
-	chainID uint32
-	account account.Account
+	chainID      uint32
+	account      account.Account
+	nonceManager NonceManager
+	encoding     iotextypes.Encoding
+}
+
+// AuthedClientOption is an option to create an AuthedClient.
+type AuthedClientOption func(*authedClient)
+
+// WithNonceManager makes the AuthedClient reserve nonces from the given NonceManager, instead of fetching the
+// pending nonce for every action. Share one NonceManager among the clients sending from the same account.
+func WithNonceManager(m NonceManager) AuthedClientOption {
+	return func(c *authedClient) {
+		c.nonceManager = m
+	}
+}
+
+// WithEthereumRLP makes the AuthedClient sign actions as EIP-155 Ethereum transactions and send them in
+// Encoding_ETHEREUM_RLP, so the action hashes are the same as the transaction hashes in Ethereum tools. The EIP-155
+// chain ID is mapped from the IoTeX chain ID by EVMNetworkID.
+func WithEthereumRLP() AuthedClientOption {
+	return func(c *authedClient) {
+		c.encoding = iotextypes.Encoding_ETHEREUM_RLP
+	}
 }
 
 // NewAuthedClient creates an AuthedClient using given account's credentials.
-func NewAuthedClient(api iotexapi.APIServiceClient, chainID uint32, a account.Account) AuthedClient {
-	return &authedClient{
+func NewAuthedClient(api iotexapi.APIServiceClient, chainID uint32, a account.Account, opts ...AuthedClientOption) AuthedClient {
+	c := &authedClient{
 		client: client{
 			api: api,
 		},
 		chainID: chainID,
 		account: a,
 	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// NewSignerClient creates an AuthedClient signing actions with the Signer, such as a remote signer, so the private key
+// does not need to be in the process memory.
+func NewSignerClient(api iotexapi.APIServiceClient, chainID uint32, s account.Signer, opts ...AuthedClientOption) AuthedClient {
+	return NewAuthedClient(api, chainID, account.SignerAccount(s), opts...)
+}
+
+func (c *authedClient) newSendActionCaller() *sendActionCaller {
+	return &sendActionCaller{
+		chainID:      c.chainID,
+		account:      c.account,
+		api:          c.api,
+		nonceManager: c.nonceManager,
+		encoding:     c.encoding,
+	}
 }
 
 func (c *authedClient) Contract(co address.Address, abi abi.ABI) Contract {
 	return &contract{
-		sendActionCaller: &sendActionCaller{
-			chainID: c.chainID,
-			account: c.account,
-			api:     c.api,
-		},
-		address: co,
-		abi:     &abi,
+		sendActionCaller: c.newSendActionCaller(),
+		address:          co,
+		abi:              &abi,
 	}
 }
 
 func (c *authedClient) Transfer(to address.Address, value *big.Int) SendActionCaller {
 	return &transferCaller{
-		sendActionCaller: &sendActionCaller{
-			chainID: c.chainID,
-			account: c.account,
-			api:     c.api,
-		},
-		amount:    value,
-		recipient: to,
+		sendActionCaller: c.newSendActionCaller(),
+		amount:           value,
+		recipient:        to,
 	}
 }
 
 func (c *authedClient) ClaimReward(value *big.Int) ClaimRewardCaller {
 	return &claimRewardCaller{
-		sendActionCaller: &sendActionCaller{
-			chainID: c.chainID,
-			account: c.account,
-			api:     c.api,
-		},
-		amount: value,
+		sendActionCaller: c.newSendActionCaller(),
+		amount:           value,
 	}
 }
 
 func (c *authedClient) DeployContract(data []byte) DeployContractCaller {
+	caller := c.newSendActionCaller()
+	caller.payload = data
 	return &deployContractCaller{
-		sendActionCaller: &sendActionCaller{
-			chainID: c.chainID,
-			account: c.account,
-			api:     c.api,
-			payload: data,
-		},
+		sendActionCaller: caller,
 	}
 }
 
-//Staking interface
+// Staking interface
 func (c *authedClient) Staking() StakingCaller {
 	return &stakingCaller{
-		sendActionCaller: &sendActionCaller{
-			chainID: c.chainID,
-			account: c.account,
-			api:     c.api,
-		}}
+		sendActionCaller: c.newSendActionCaller(),
+	}
 }
 
-//Candidate interface
+// Candidate interface
 func (c *authedClient) Candidate() CandidateCaller {
 	return &stakingCaller{
-		sendActionCaller: &sendActionCaller{
-			chainID: c.chainID,
-			account: c.account,
-			api:     c.api,
-		}}
+		sendActionCaller: c.newSendActionCaller(),
+	}
 }
 
 func (c *authedClient) Account() account.Account { return c.account }
@@ -1,9 +1,16 @@
 package iotex
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+
 	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/iotexproject/iotex-address/address"
 	"github.com/iotexproject/iotex-proto/golang/iotexapi"
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
 )
 
 // Data is the data returned from read contract.
@@ -16,6 +23,54 @@ type Data struct {
 // Unmarshal unmarshals data into a data holder object.
 func (d Data) Unmarshal() ([]interface{}, error) { return d.abi.Unpack(d.method, d.Raw) }
 
+// UnmarshalInto unmarshals data into the value pointed to by out, with the semantics of abi.Arguments.Copy. Multiple
+// outputs go into a struct by the output names, or into a slice or an array in order, and a single output goes into
+// the value itself. Tuple outputs go into structs by the component names, and addresses go into address.Address,
+// common.Address or io1 strings.
+func (d Data) UnmarshalInto(out interface{}) error {
+	method, ok := d.abi.Methods[d.method]
+	if !ok {
+		return fmt.Errorf("method %s is not found", d.method)
+	}
+	values, err := d.abi.Unpack(d.method, d.Raw)
+	if err != nil {
+		return err
+	}
+	dst := reflect.ValueOf(out)
+	if dst.Kind() != reflect.Ptr || dst.IsNil() {
+		return fmt.Errorf("unmarshal into non-pointer or nil %T", out)
+	}
+	return decodeOutputs(method.Outputs, values, dst.Elem())
+}
+
+// UnmarshalIntoMap unmarshals data into the map keyed by the output names, or by the positions such as "0" for the
+// unnamed outputs. Addresses, including the ones in slices and arrays, are converted to address.Address.
+func (d Data) UnmarshalIntoMap(out map[string]interface{}) error {
+	if out == nil {
+		return errors.New("unmarshal into nil map")
+	}
+	method, ok := d.abi.Methods[d.method]
+	if !ok {
+		return fmt.Errorf("method %s is not found", d.method)
+	}
+	values, err := d.abi.Unpack(d.method, d.Raw)
+	if err != nil {
+		return err
+	}
+	for i, output := range method.Outputs {
+		v, err := ioAddressValue(reflect.ValueOf(values[i]))
+		if err != nil {
+			return err
+		}
+		name := output.Name
+		if name == "" {
+			name = strconv.Itoa(i)
+		}
+		out[name] = v.Interface()
+	}
+	return nil
+}
+
 type contract struct {
 	*sendActionCaller
 	address address.Address
@@ -46,6 +101,14 @@ func (c *contract) Execute(method string, args ...interface{}) ExecuteContractCa
 	}
 }
 
+func (c *contract) ParseLog(log *iotextypes.Log) (*Event, error) {
+	return parseLog(c.abi, c.address, log)
+}
+
+func (c *contract) FilterEvents(ctx context.Context, eventName string, fromHeight, toHeight uint64, filters ...[]interface{}) ([]*Event, error) {
+	return filterEvents(ctx, c.api, c.abi, c.address, eventName, fromHeight, toHeight, filters)
+}
+
 type readOnlyContract struct {
 	address address.Address
 	abi     *abi.ABI
@@ -63,3 +126,11 @@ func (c *readOnlyContract) Read(method string, args ...interface{}) ReadContract
 		},
 	}
 }
+
+func (c *readOnlyContract) ParseLog(log *iotextypes.Log) (*Event, error) {
+	return parseLog(c.abi, c.address, log)
+}
+
+func (c *readOnlyContract) FilterEvents(ctx context.Context, eventName string, fromHeight, toHeight uint64, filters ...[]interface{}) ([]*Event, error) {
+	return filterEvents(ctx, c.api, c.abi, c.address, eventName, fromHeight, toHeight, filters)
+}
@@ -0,0 +1,53 @@
+// Copyright (c) 2020 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package iotex
+
+import (
+	"context"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+	"google.golang.org/grpc"
+
+	"github.com/iotexproject/iotex-antenna-go/v2/errcodes"
+)
+
+// RawEthTxToAction decodes a raw signed Ethereum transaction, as returned by eth_signTransaction, into an action.
+// See EthTxToAction.
+func RawEthTxToAction(raw []byte, chainID uint32) (*iotextypes.Action, error) {
+	tx := &types.Transaction{}
+	if err := tx.UnmarshalBinary(raw); err != nil {
+		return nil, errcodes.NewError(err, errcodes.InvalidParam)
+	}
+	act, err := EthTxToAction(tx, chainID)
+	if err != nil {
+		return nil, errcodes.NewError(err, errcodes.InvalidParam)
+	}
+	return act, nil
+}
+
+// SendRawEthTx relays a raw signed Ethereum transaction to the chain, and returns the action hash, which is the same
+// as the Ethereum transaction hash.
+func SendRawEthTx(ctx context.Context, c ReadOnlyClient, chainID uint32, raw []byte, opts ...grpc.CallOption) (hash.Hash256, error) {
+	act, err := RawEthTxToAction(raw, chainID)
+	if err != nil {
+		return hash.ZeroHash256, err
+	}
+	expected, err := ActionHash(act, EVMNetworkID(chainID))
+	if err != nil {
+		return hash.ZeroHash256, errcodes.NewError(err, errcodes.InternalError)
+	}
+	h, err := broadcast(ctx, c.API(), act, opts...)
+	if err != nil {
+		return hash.ZeroHash256, err
+	}
+	if h != expected {
+		return hash.ZeroHash256, errcodes.New("action hash returned by the node does not match the tx hash", errcodes.BadResponse)
+	}
+	return h, nil
+}
@@ -0,0 +1,86 @@
+// Copyright (c) 2020 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package iotex
+
+import (
+	"context"
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	ethCrypto "github.com/ethereum/go-ethereum/crypto"
+	"github.com/golang/mock/gomock"
+	"github.com/iotexproject/iotex-address/address"
+	"github.com/iotexproject/iotex-proto/golang/iotexapi"
+	"github.com/iotexproject/iotex-proto/golang/iotexapi/mock_iotexapi"
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-antenna-go/v2/account"
+)
+
+func TestSendRawEthTx(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	acc, err := account.HexStringToAccount(_accountPrivateKey)
+	require.NoError(err)
+	sk, err := ethCrypto.HexToECDSA(_accountPrivateKey)
+	require.NoError(err)
+	contract, err := address.FromString("io17sn486alutrnzlrdz9vv44g7qyc38hygf7s6h0")
+	require.NoError(err)
+	signTx := func(evmID int64, tx *types.Transaction) []byte {
+		signed, err := types.SignTx(tx, types.NewEIP155Signer(big.NewInt(evmID)), sk)
+		require.NoError(err)
+		raw, err := signed.MarshalBinary()
+		require.NoError(err)
+		return raw
+	}
+	data := []byte{0x60, 0xfe, 0x47, 0xb1}
+	raw := signTx(TestnetEVMNetworkID, types.NewTransaction(9, toEthAddress(contract), big.NewInt(1), 50000, big.NewInt(1000000000000), data))
+
+	act, err := RawEthTxToAction(raw, 2)
+	require.NoError(err)
+	require.Equal(iotextypes.Encoding_ETHEREUM_RLP, act.GetEncoding())
+	require.Equal(acc.PublicKey().Bytes(), act.GetSenderPubKey())
+	require.EqualValues(9, act.GetCore().GetNonce())
+	require.EqualValues(50000, act.GetCore().GetGasLimit())
+	require.Equal("1000000000000", act.GetCore().GetGasPrice())
+	require.EqualValues(2, act.GetCore().GetChainID())
+	require.Equal(contract.String(), act.GetCore().GetExecution().GetContract())
+	require.Equal("1", act.GetCore().GetExecution().GetAmount())
+	require.Equal(data, act.GetCore().GetExecution().GetData())
+
+	tx := &types.Transaction{}
+	require.NoError(tx.UnmarshalBinary(raw))
+	api := mock_iotexapi.NewMockAPIServiceClient(ctrl)
+	api.EXPECT().SendAction(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, in *iotexapi.SendActionRequest, _ ...interface{}) (*iotexapi.SendActionResponse, error) {
+			require.Equal(act.GetSignature(), in.GetAction().GetSignature())
+			return &iotexapi.SendActionResponse{ActionHash: hex.EncodeToString(tx.Hash().Bytes())}, nil
+		})
+	h, err := SendRawEthTx(context.Background(), NewReadOnlyClient(api), 2, raw)
+	require.NoError(err)
+	require.Equal(tx.Hash().Bytes(), h[:])
+
+	// transfer
+	raw = signTx(TestnetEVMNetworkID, types.NewTransaction(10, toEthAddress(contract), big.NewInt(2), 21000, big.NewInt(1000000000000), nil))
+	act, err = RawEthTxToAction(raw, 2)
+	require.NoError(err)
+	require.Equal(contract.String(), act.GetCore().GetTransfer().GetRecipient())
+	require.Equal("2", act.GetCore().GetTransfer().GetAmount())
+
+	// signed for mainnet
+	raw = signTx(MainnetEVMNetworkID, types.NewTransaction(10, toEthAddress(contract), big.NewInt(2), 21000, big.NewInt(1000000000000), nil))
+	_, err = RawEthTxToAction(raw, 2)
+	require.Error(err)
+
+	_, err = RawEthTxToAction([]byte{1, 2, 3}, 2)
+	require.Error(err)
+}
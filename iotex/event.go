@@ -0,0 +1,163 @@
+// Copyright (c) 2020 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package iotex
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/iotexproject/iotex-address/address"
+	"github.com/iotexproject/iotex-proto/golang/iotexapi"
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+
+	"github.com/iotexproject/iotex-antenna-go/v2/errcodes"
+)
+
+// Event is an event log decoded by the ABI of the contract
+type Event struct {
+	// Name is the name of the event
+	Name string
+	// Fields are the indexed and non-indexed arguments keyed by the names, with addresses in address.Address. The
+	// indexed arguments of the dynamic types, such as string, are the hashes of the values.
+	Fields map[string]interface{}
+	// Log is the raw log
+	Log *iotextypes.Log
+
+	event  abi.Event
+	values map[string]interface{}
+}
+
+// UnmarshalInto unmarshals the arguments of the event into the struct pointed to by out, by the argument names. Tuples
+// go into structs, and addresses go into address.Address, common.Address or io1 strings.
+func (e *Event) UnmarshalInto(out interface{}) error {
+	dst := reflect.ValueOf(out)
+	if dst.Kind() != reflect.Ptr || dst.IsNil() || dst.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("unmarshal into %T, not a pointer to struct", out)
+	}
+	dst = dst.Elem()
+	for _, input := range e.event.Inputs {
+		field := structField(dst, input.Name)
+		if !field.IsValid() {
+			return fmt.Errorf("argument %s can't be found in %s", input.Name, dst.Type())
+		}
+		if err := decodeValue(field, reflect.ValueOf(e.values[input.Name])); err != nil {
+			return fmt.Errorf("argument %s: %v", input.Name, err)
+		}
+	}
+	return nil
+}
+
+// parseLog decodes the log emitted by the contract
+func parseLog(contractABI *abi.ABI, contract address.Address, log *iotextypes.Log) (*Event, error) {
+	if log.GetContractAddress() != contract.String() {
+		return nil, errcodes.New(fmt.Sprintf("log of %s, not of the contract %s", log.GetContractAddress(), contract), errcodes.InvalidParam)
+	}
+	if len(log.GetTopics()) == 0 {
+		return nil, errcodes.New("anonymous log is not supported", errcodes.InvalidParam)
+	}
+	event, err := contractABI.EventByID(common.BytesToHash(log.GetTopics()[0]))
+	if err != nil {
+		return nil, errcodes.NewError(err, errcodes.InvalidParam)
+	}
+	values := make(map[string]interface{})
+	if err := event.Inputs.UnpackIntoMap(values, log.GetData()); err != nil {
+		return nil, errcodes.NewError(err, errcodes.InvalidParam)
+	}
+	var indexed abi.Arguments
+	for _, input := range event.Inputs {
+		if input.Indexed {
+			indexed = append(indexed, input)
+		}
+	}
+	topics := make([]common.Hash, len(log.GetTopics())-1)
+	for i, t := range log.GetTopics()[1:] {
+		topics[i] = common.BytesToHash(t)
+	}
+	if err := abi.ParseTopicsIntoMap(values, indexed, topics); err != nil {
+		return nil, errcodes.NewError(err, errcodes.InvalidParam)
+	}
+	fields := make(map[string]interface{}, len(values))
+	for name, v := range values {
+		ioValue, err := ioAddressValue(reflect.ValueOf(v))
+		if err != nil {
+			return nil, errcodes.NewError(err, errcodes.InvalidParam)
+		}
+		fields[name] = ioValue.Interface()
+	}
+	return &Event{
+		Name:   event.Name,
+		Fields: fields,
+		Log:    log,
+		event:  *event,
+		values: values,
+	}, nil
+}
+
+// filterEvents gets the logs of the event emitted by the contract in the range of heights, and decodes them. filters
+// are the values of the indexed arguments in order, any of which can match, and nil or empty matches any value.
+func filterEvents(ctx context.Context, api iotexapi.APIServiceClient, contractABI *abi.ABI, contract address.Address,
+	eventName string, fromHeight, toHeight uint64, filters [][]interface{}) ([]*Event, error) {
+	event, ok := contractABI.Events[eventName]
+	if !ok {
+		return nil, errcodes.New("event is not found", errcodes.InvalidParam)
+	}
+	var indexed abi.Arguments
+	for _, input := range event.Inputs {
+		if input.Indexed {
+			indexed = append(indexed, input)
+		}
+	}
+	if len(filters) > len(indexed) {
+		return nil, errcodes.New(fmt.Sprintf("%d filters, more than %d indexed arguments", len(filters), len(indexed)), errcodes.InvalidParam)
+	}
+	query := make([][]interface{}, len(filters))
+	for i, values := range filters {
+		for _, v := range values {
+			encoded, err := encodeValue(indexed[i].Type, v, indexed[i].Name)
+			if err != nil {
+				return nil, errcodes.NewError(err, errcodes.InvalidParam)
+			}
+			query[i] = append(query[i], encoded)
+		}
+	}
+	topics, err := abi.MakeTopics(query...)
+	if err != nil {
+		return nil, errcodes.NewError(err, errcodes.InvalidParam)
+	}
+	filter := &iotexapi.LogsFilter{
+		Address: []string{contract.String()},
+		Topics:  []*iotexapi.Topics{{Topic: [][]byte{event.ID.Bytes()}}},
+	}
+	for _, t := range topics {
+		ts := &iotexapi.Topics{}
+		for _, topic := range t {
+			ts.Topic = append(ts.Topic, topic.Bytes())
+		}
+		filter.Topics = append(filter.Topics, ts)
+	}
+	response, err := api.GetLogs(ctx, &iotexapi.GetLogsRequest{
+		Filter: filter,
+		Lookup: &iotexapi.GetLogsRequest_ByRange{
+			ByRange: &iotexapi.GetLogsByRange{FromBlock: fromHeight, ToBlock: toHeight},
+		},
+	})
+	if err != nil {
+		return nil, errcodes.NewError(err, errcodes.RPCError)
+	}
+	events := make([]*Event, 0, len(response.GetLogs()))
+	for _, log := range response.GetLogs() {
+		e, err := parseLog(contractABI, contract, log)
+		if err != nil {
+			return nil, errcodes.NewError(err, errcodes.BadResponse)
+		}
+		events = append(events, e)
+	}
+	return events, nil
+}
@@ -0,0 +1,113 @@
+// Copyright (c) 2020 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package iotex
+
+import (
+	"context"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/golang/mock/gomock"
+	"github.com/iotexproject/iotex-address/address"
+	"github.com/iotexproject/iotex-proto/golang/iotexapi"
+	"github.com/iotexproject/iotex-proto/golang/iotexapi/mock_iotexapi"
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	_ EventFilterer = (*contract)(nil)
+	_ EventFilterer = (*readOnlyContract)(nil)
+)
+
+const _transferABI = `[{"anonymous":false,"inputs":[{"indexed":true,"name":"from","type":"address"},{"indexed":true,"name":"to","type":"address"},{"indexed":false,"name":"value","type":"uint256"},{"indexed":false,"name":"memo","type":"string"}],"name":"Transfer","type":"event"}]`
+
+func TestContractEvents(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	contractABI, err := abi.JSON(strings.NewReader(_transferABI))
+	require.NoError(err)
+	contract, err := address.FromString("io17sn486alutrnzlrdz9vv44g7qyc38hygf7s6h0")
+	require.NoError(err)
+	from, err := address.FromString("io18jaldgzc8wlyfnzamgas62yu3kg5nw527czg37")
+	require.NoError(err)
+	to, err := address.FromString("io1ntprz4p5zw38fvtfrcczjtcv3rkr3nqs6sm3pj")
+	require.NoError(err)
+	event := contractABI.Events["Transfer"]
+	data, err := event.Inputs.NonIndexed().Pack(big.NewInt(100), "hello")
+	require.NoError(err)
+	log := &iotextypes.Log{
+		ContractAddress: contract.String(),
+		Topics: [][]byte{
+			event.ID.Bytes(),
+			common.BytesToHash(from.Bytes()).Bytes(),
+			common.BytesToHash(to.Bytes()).Bytes(),
+		},
+		Data:      data,
+		BlkHeight: 15,
+	}
+
+	api := mock_iotexapi.NewMockAPIServiceClient(ctrl)
+	api.EXPECT().GetLogs(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, in *iotexapi.GetLogsRequest, _ ...interface{}) (*iotexapi.GetLogsResponse, error) {
+			require.Equal([]string{contract.String()}, in.GetFilter().GetAddress())
+			topics := in.GetFilter().GetTopics()
+			require.Len(topics, 3)
+			require.Equal([][]byte{event.ID.Bytes()}, topics[0].GetTopic())
+			require.Empty(topics[1].GetTopic())
+			require.Equal([][]byte{common.BytesToHash(to.Bytes()).Bytes(), common.BytesToHash(from.Bytes()).Bytes()}, topics[2].GetTopic())
+			require.EqualValues(10, in.GetByRange().GetFromBlock())
+			require.EqualValues(20, in.GetByRange().GetToBlock())
+			return &iotexapi.GetLogsResponse{Logs: []*iotextypes.Log{log}}, nil
+		})
+
+	c := NewReadOnlyClient(api).ReadOnlyContract(contract, contractABI).(EventFilterer)
+	events, err := c.FilterEvents(context.Background(), "Transfer", 10, 20, nil, []interface{}{to.String(), from})
+	require.NoError(err)
+	require.Len(events, 1)
+	e := events[0]
+	require.Equal("Transfer", e.Name)
+	require.Equal(log, e.Log)
+	require.Equal(from.String(), e.Fields["from"].(address.Address).String())
+	require.Equal(to.String(), e.Fields["to"].(address.Address).String())
+	require.Equal(big.NewInt(100), e.Fields["value"])
+	require.Equal("hello", e.Fields["memo"])
+
+	var transfer struct {
+		From  address.Address
+		To    common.Address
+		Value *big.Int
+		Memo  string
+	}
+	require.NoError(e.UnmarshalInto(&transfer))
+	require.Equal(from.String(), transfer.From.String())
+	require.Equal(common.BytesToAddress(to.Bytes()), transfer.To)
+	require.Equal(big.NewInt(100), transfer.Value)
+	require.Equal("hello", transfer.Memo)
+
+	// ParseLog checks the contract and the event
+	e, err = c.ParseLog(log)
+	require.NoError(err)
+	require.Equal("Transfer", e.Name)
+	_, err = c.ParseLog(&iotextypes.Log{ContractAddress: from.String(), Topics: log.Topics, Data: data})
+	require.Error(err)
+	_, err = c.ParseLog(&iotextypes.Log{ContractAddress: contract.String(), Topics: [][]byte{common.Hash{}.Bytes()}})
+	require.Error(err)
+
+	// the filters are checked by the ABI
+	_, err = c.FilterEvents(context.Background(), "Approval", 10, 20)
+	require.Error(err)
+	_, err = c.FilterEvents(context.Background(), "Transfer", 10, 20, []interface{}{"io1x"})
+	require.Error(err)
+	_, err = c.FilterEvents(context.Background(), "Transfer", 10, 20, nil, nil, []interface{}{big.NewInt(1)})
+	require.Error(err)
+}
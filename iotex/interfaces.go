@@ -4,11 +4,13 @@ import (
 	"context"
 	"math/big"
 
+	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/iotexproject/go-pkgs/hash"
 	"github.com/iotexproject/iotex-address/address"
 	"github.com/iotexproject/iotex-antenna-go/v2/account"
 	"github.com/iotexproject/iotex-proto/golang/iotexapi"
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
 	"google.golang.org/grpc"
 )
 
@@ -18,6 +20,12 @@ type Caller interface {
 	Call(ctx context.Context, opts ...grpc.CallOption) (hash.Hash256, error)
 }
 
+// Builder is implemented by the send action callers of AuthedClient, to build the action instead of sending it.
+type Builder interface {
+	// Build returns the unsigned action to be signed offline, instead of signing and sending it
+	Build(ctx context.Context, opts ...grpc.CallOption) (*UnsignedAction, error)
+}
+
 // SendActionCaller is used to set nonce/gas etc. on top of Caller
 type SendActionCaller interface {
 	Caller
@@ -70,6 +78,23 @@ type ReadOnlyClient interface {
 	API() iotexapi.APIServiceClient
 }
 
+// Subscriber is implemented by the clients of NewReadOnlyClient and NewAuthedClient, to follow the new blocks.
+type Subscriber interface {
+	// SubscribeBlocks delivers the new blocks in order, with the receipts. It reconnects on errors and resumes from the
+	// height after the last delivered block, so no block is missed or duplicated. The channel is closed when the
+	// subscription ends.
+	SubscribeBlocks(ctx context.Context, opts ...SubscribeOption) (<-chan *iotexapi.BlockInfo, ethereum.Subscription, error)
+	// SubscribeLogs delivers the logs matching the filter in the new blocks in order, the same as SubscribeBlocks. A
+	// log of a greater height tells all the logs of the previous heights are delivered.
+	SubscribeLogs(ctx context.Context, filter *iotexapi.LogsFilter, opts ...SubscribeOption) (<-chan *iotextypes.Log, ethereum.Subscription, error)
+}
+
+// Broadcaster is implemented by the clients of NewReadOnlyClient and NewAuthedClient, to send the signed actions.
+type Broadcaster interface {
+	// Broadcast sends an action signed elsewhere, e.g. by UnsignedAction.Sign on an offline machine
+	Broadcast(ctx context.Context, act *iotextypes.Action, opts ...grpc.CallOption) (hash.Hash256, error)
+}
+
 // ReadContractCaller is used to perform a read contract call.
 type ReadContractCaller interface {
 	Call(ctx context.Context, opts ...grpc.CallOption) (Data, error)
@@ -107,6 +132,15 @@ type ReadOnlyContract interface {
 	Read(method string, args ...interface{}) ReadContractCaller
 }
 
+// EventFilterer is implemented by the contracts of NewReadOnlyClient and NewAuthedClient, to decode the event logs.
+type EventFilterer interface {
+	// ParseLog decodes the event log emitted by this contract
+	ParseLog(log *iotextypes.Log) (*Event, error)
+	// FilterEvents gets and decodes the event logs in the range of heights. filters are the values of the indexed
+	// arguments in order, any of which can match, and nil or empty matches any value.
+	FilterEvents(ctx context.Context, eventName string, fromHeight, toHeight uint64, filters ...[]interface{}) ([]*Event, error)
+}
+
 // StakingCaller is used to perform a staking call.
 type StakingCaller interface {
 	Create(candidateName string, amount *big.Int, duration uint32, autoStake bool) SendActionCaller
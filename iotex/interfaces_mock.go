@@ -9,12 +9,14 @@ import (
 	big "math/big"
 	reflect "reflect"
 
+	go_ethereum "github.com/ethereum/go-ethereum"
 	abi "github.com/ethereum/go-ethereum/accounts/abi"
 	gomock "github.com/golang/mock/gomock"
 	hash "github.com/iotexproject/go-pkgs/hash"
 	address "github.com/iotexproject/iotex-address/address"
 	account "github.com/iotexproject/iotex-antenna-go/v2/account"
 	iotexapi "github.com/iotexproject/iotex-proto/golang/iotexapi"
+	iotextypes "github.com/iotexproject/iotex-proto/golang/iotextypes"
 	grpc "google.golang.org/grpc"
 )
 
@@ -75,6 +77,49 @@ func (mr *MockCallerMockRecorder) Call(ctx interface{}, opts ...interface{}) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Call", reflect.TypeOf((*MockCaller)(nil).Call), varargs...)
 }
 
+// MockBuilder is a mock of Builder interface.
+type MockBuilder struct {
+	ctrl     *gomock.Controller
+	recorder *MockBuilderMockRecorder
+}
+
+// MockBuilderMockRecorder is the mock recorder for MockBuilder.
+type MockBuilderMockRecorder struct {
+	mock *MockBuilder
+}
+
+// NewMockBuilder creates a new mock instance.
+func NewMockBuilder(ctrl *gomock.Controller) *MockBuilder {
+	mock := &MockBuilder{ctrl: ctrl}
+	mock.recorder = &MockBuilderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBuilder) EXPECT() *MockBuilderMockRecorder {
+	return m.recorder
+}
+
+// Build mocks base method.
+func (m *MockBuilder) Build(ctx context.Context, opts ...grpc.CallOption) (*UnsignedAction, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Build", varargs...)
+	ret0, _ := ret[0].(*UnsignedAction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Build indicates an expected call of Build.
+func (mr *MockBuilderMockRecorder) Build(ctx interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Build", reflect.TypeOf((*MockBuilder)(nil).Build), varargs...)
+}
+
 // MockSendActionCaller is a mock of SendActionCaller interface.
 type MockSendActionCaller struct {
 	ctrl     *gomock.Controller
@@ -452,6 +497,20 @@ func (mr *MockAuthedClientMockRecorder) Candidate() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Candidate", reflect.TypeOf((*MockAuthedClient)(nil).Candidate))
 }
 
+// ChainID mocks base method.
+func (m *MockAuthedClient) ChainID() uint32 {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChainID")
+	ret0, _ := ret[0].(uint32)
+	return ret0
+}
+
+// ChainID indicates an expected call of ChainID.
+func (mr *MockAuthedClientMockRecorder) ChainID() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChainID", reflect.TypeOf((*MockAuthedClient)(nil).ChainID))
+}
+
 // ClaimReward mocks base method.
 func (m *MockAuthedClient) ClaimReward(value *big.Int) ClaimRewardCaller {
 	m.ctrl.T.Helper()
@@ -643,6 +702,114 @@ func (mr *MockReadOnlyClientMockRecorder) ReadOnlyContract(contract, abi interfa
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadOnlyContract", reflect.TypeOf((*MockReadOnlyClient)(nil).ReadOnlyContract), contract, abi)
 }
 
+// MockSubscriber is a mock of Subscriber interface.
+type MockSubscriber struct {
+	ctrl     *gomock.Controller
+	recorder *MockSubscriberMockRecorder
+}
+
+// MockSubscriberMockRecorder is the mock recorder for MockSubscriber.
+type MockSubscriberMockRecorder struct {
+	mock *MockSubscriber
+}
+
+// NewMockSubscriber creates a new mock instance.
+func NewMockSubscriber(ctrl *gomock.Controller) *MockSubscriber {
+	mock := &MockSubscriber{ctrl: ctrl}
+	mock.recorder = &MockSubscriberMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSubscriber) EXPECT() *MockSubscriberMockRecorder {
+	return m.recorder
+}
+
+// SubscribeBlocks mocks base method.
+func (m *MockSubscriber) SubscribeBlocks(ctx context.Context, opts ...SubscribeOption) (<-chan *iotexapi.BlockInfo, go_ethereum.Subscription, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "SubscribeBlocks", varargs...)
+	ret0, _ := ret[0].(<-chan *iotexapi.BlockInfo)
+	ret1, _ := ret[1].(go_ethereum.Subscription)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// SubscribeBlocks indicates an expected call of SubscribeBlocks.
+func (mr *MockSubscriberMockRecorder) SubscribeBlocks(ctx interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubscribeBlocks", reflect.TypeOf((*MockSubscriber)(nil).SubscribeBlocks), varargs...)
+}
+
+// SubscribeLogs mocks base method.
+func (m *MockSubscriber) SubscribeLogs(ctx context.Context, filter *iotexapi.LogsFilter, opts ...SubscribeOption) (<-chan *iotextypes.Log, go_ethereum.Subscription, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, filter}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "SubscribeLogs", varargs...)
+	ret0, _ := ret[0].(<-chan *iotextypes.Log)
+	ret1, _ := ret[1].(go_ethereum.Subscription)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// SubscribeLogs indicates an expected call of SubscribeLogs.
+func (mr *MockSubscriberMockRecorder) SubscribeLogs(ctx, filter interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, filter}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubscribeLogs", reflect.TypeOf((*MockSubscriber)(nil).SubscribeLogs), varargs...)
+}
+
+// MockBroadcaster is a mock of Broadcaster interface.
+type MockBroadcaster struct {
+	ctrl     *gomock.Controller
+	recorder *MockBroadcasterMockRecorder
+}
+
+// MockBroadcasterMockRecorder is the mock recorder for MockBroadcaster.
+type MockBroadcasterMockRecorder struct {
+	mock *MockBroadcaster
+}
+
+// NewMockBroadcaster creates a new mock instance.
+func NewMockBroadcaster(ctrl *gomock.Controller) *MockBroadcaster {
+	mock := &MockBroadcaster{ctrl: ctrl}
+	mock.recorder = &MockBroadcasterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBroadcaster) EXPECT() *MockBroadcasterMockRecorder {
+	return m.recorder
+}
+
+// Broadcast mocks base method.
+func (m *MockBroadcaster) Broadcast(ctx context.Context, act *iotextypes.Action, opts ...grpc.CallOption) (hash.Hash256, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, act}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Broadcast", varargs...)
+	ret0, _ := ret[0].(hash.Hash256)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Broadcast indicates an expected call of Broadcast.
+func (mr *MockBroadcasterMockRecorder) Broadcast(ctx, act interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, act}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Broadcast", reflect.TypeOf((*MockBroadcaster)(nil).Broadcast), varargs...)
+}
+
 // MockReadContractCaller is a mock of ReadContractCaller interface.
 type MockReadContractCaller struct {
 	ctrl     *gomock.Controller
@@ -1020,6 +1187,64 @@ func (mr *MockReadOnlyContractMockRecorder) Read(method interface{}, args ...int
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Read", reflect.TypeOf((*MockReadOnlyContract)(nil).Read), varargs...)
 }
 
+// MockEventFilterer is a mock of EventFilterer interface.
+type MockEventFilterer struct {
+	ctrl     *gomock.Controller
+	recorder *MockEventFiltererMockRecorder
+}
+
+// MockEventFiltererMockRecorder is the mock recorder for MockEventFilterer.
+type MockEventFiltererMockRecorder struct {
+	mock *MockEventFilterer
+}
+
+// NewMockEventFilterer creates a new mock instance.
+func NewMockEventFilterer(ctrl *gomock.Controller) *MockEventFilterer {
+	mock := &MockEventFilterer{ctrl: ctrl}
+	mock.recorder = &MockEventFiltererMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockEventFilterer) EXPECT() *MockEventFiltererMockRecorder {
+	return m.recorder
+}
+
+// FilterEvents mocks base method.
+func (m *MockEventFilterer) FilterEvents(ctx context.Context, eventName string, fromHeight, toHeight uint64, filters ...[]interface{}) ([]*Event, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, eventName, fromHeight, toHeight}
+	for _, a := range filters {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "FilterEvents", varargs...)
+	ret0, _ := ret[0].([]*Event)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FilterEvents indicates an expected call of FilterEvents.
+func (mr *MockEventFiltererMockRecorder) FilterEvents(ctx, eventName, fromHeight, toHeight interface{}, filters ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, eventName, fromHeight, toHeight}, filters...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FilterEvents", reflect.TypeOf((*MockEventFilterer)(nil).FilterEvents), varargs...)
+}
+
+// ParseLog mocks base method.
+func (m *MockEventFilterer) ParseLog(log *iotextypes.Log) (*Event, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ParseLog", log)
+	ret0, _ := ret[0].(*Event)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ParseLog indicates an expected call of ParseLog.
+func (mr *MockEventFiltererMockRecorder) ParseLog(log interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ParseLog", reflect.TypeOf((*MockEventFilterer)(nil).ParseLog), log)
+}
+
 // MockStakingCaller is a mock of StakingCaller interface.
 type MockStakingCaller struct {
 	ctrl     *gomock.Controller
@@ -0,0 +1,228 @@
+// Copyright (c) 2020 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package iotex
+
+import (
+	"context"
+	"strings"
+
+	"github.com/iotexproject/iotex-proto/golang/iotexapi"
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/iotexproject/iotex-antenna-go/v2/errcodes"
+)
+
+const (
+	_defaultChunkSize    = 1000
+	_defaultMaxChunkSize = 100000
+	_defaultParallelism  = 4
+	// the chunk grows when a chunk has fewer logs
+	_sparseLogs = 100
+)
+
+// LogScanner scans the logs of a range of heights in chunks, for backfilling the history
+type LogScanner interface {
+	// Scan gets the logs matching the filter from height from to height to, inclusive, or to the tip if to is 0. fn
+	// is called with the logs of each chunk in the order of heights, and next, the height after the chunk, from which
+	// a stopped scan resumes. The scan stops at the first error of fn.
+	Scan(ctx context.Context, filter *iotexapi.LogsFilter, from, to uint64, fn func(logs []*iotextypes.Log, next uint64) error) error
+}
+
+// LogScannerOption is an option of the LogScanner
+type LogScannerOption func(*logScanner)
+
+type (
+	logScanner struct {
+		api          iotexapi.APIServiceClient
+		chunkSize    uint64
+		maxChunkSize uint64
+		parallelism  int
+		isLimitError func(error) bool
+	}
+
+	heightRange struct {
+		from, to uint64
+	}
+
+	chunkResult struct {
+		r    heightRange
+		logs []*iotextypes.Log
+		err  error
+	}
+)
+
+// WithChunkSize sets the number of heights of the first chunk and the max number of heights of a chunk, default
+// are 1000 and 100000
+func WithChunkSize(initial, max uint64) LogScannerOption {
+	return func(s *logScanner) {
+		if max == 0 {
+			max = 1
+		}
+		if initial == 0 || initial > max {
+			initial = max
+		}
+		s.chunkSize, s.maxChunkSize = initial, max
+	}
+}
+
+// WithParallelism sets the max number of chunks fetched at the same time, default is 4
+func WithParallelism(n int) LogScannerOption {
+	return func(s *logScanner) {
+		if n > 0 {
+			s.parallelism = n
+		}
+	}
+}
+
+// WithLimitError sets the function telling the error of a chunk is caused by the limits of the node, on which the
+// chunk is split into halves. The default takes the gRPC errors of InvalidArgument, ResourceExhausted and OutOfRange,
+// and the errors saying the limit is exceeded.
+func WithLimitError(f func(error) bool) LogScannerOption {
+	return func(s *logScanner) {
+		s.isLimitError = f
+	}
+}
+
+// NewLogScanner creates a LogScanner on the API. The chunk is halved when the node rejects it for the limits, and
+// doubled when its logs are sparse.
+func NewLogScanner(api iotexapi.APIServiceClient, opts ...LogScannerOption) LogScanner {
+	s := &logScanner{
+		api:          api,
+		chunkSize:    _defaultChunkSize,
+		maxChunkSize: _defaultMaxChunkSize,
+		parallelism:  _defaultParallelism,
+		isLimitError: isLimitError,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+func (s *logScanner) Scan(ctx context.Context, filter *iotexapi.LogsFilter, from, to uint64, fn func(logs []*iotextypes.Log, next uint64) error) error {
+	if from == 0 {
+		from = 1
+	}
+	if to == 0 {
+		response, err := s.api.GetChainMeta(ctx, &iotexapi.GetChainMetaRequest{})
+		if err != nil {
+			return errcodes.NewError(err, errcodes.RPCError)
+		}
+		to = response.GetChainMeta().GetHeight()
+	}
+	if from > to {
+		return nil
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	var (
+		results  = make(chan chunkResult)
+		size     = s.chunkSize
+		next     = from
+		frontier = true
+		inflight int
+		// the ranges not delivered yet in order, and the logs of the fetched ones
+		pending []heightRange
+		fetched = make(map[uint64][]*iotextypes.Log)
+		// the halves of the split chunks to fetch again, in order
+		retries []heightRange
+	)
+	fetch := func(r heightRange) {
+		inflight++
+		go func() {
+			response, err := s.api.GetLogs(ctx, &iotexapi.GetLogsRequest{
+				Filter: filter,
+				Lookup: &iotexapi.GetLogsRequest_ByRange{
+					ByRange: &iotexapi.GetLogsByRange{FromBlock: r.from, ToBlock: r.to},
+				},
+			})
+			select {
+			case results <- chunkResult{r: r, logs: response.GetLogs(), err: err}:
+			case <-ctx.Done():
+			}
+		}()
+	}
+	for {
+		for inflight < s.parallelism && len(retries) > 0 {
+			fetch(retries[0])
+			retries = retries[1:]
+		}
+		// bound the fetched logs waiting for a slow chunk before them
+		for inflight < s.parallelism && frontier && len(pending) < 2*s.parallelism {
+			r := heightRange{from: next, to: to}
+			if to-next >= size {
+				r.to = next + size - 1
+			}
+			pending = append(pending, r)
+			fetch(r)
+			if r.to == to {
+				frontier = false
+			} else {
+				next = r.to + 1
+			}
+		}
+		if inflight == 0 {
+			return nil
+		}
+
+		var res chunkResult
+		select {
+		case res = <-results:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+		inflight--
+		switch {
+		case res.err != nil && s.isLimitError(res.err) && res.r.from < res.r.to:
+			mid := res.r.from + (res.r.to-res.r.from)/2
+			halves := []heightRange{{res.r.from, mid}, {mid + 1, res.r.to}}
+			for i, r := range pending {
+				if r == res.r {
+					pending = append(pending[:i], append(halves, pending[i+1:]...)...)
+					break
+				}
+			}
+			retries = append(halves, retries...)
+			if half := mid - res.r.from + 1; size > half {
+				size = half
+			}
+			continue
+		case res.err != nil:
+			return errcodes.NewError(res.err, errcodes.RPCError)
+		}
+		if len(res.logs) < _sparseLogs && size < s.maxChunkSize {
+			size *= 2
+			if size > s.maxChunkSize {
+				size = s.maxChunkSize
+			}
+		}
+		fetched[res.r.from] = res.logs
+		for len(pending) > 0 {
+			logs, ok := fetched[pending[0].from]
+			if !ok {
+				break
+			}
+			delete(fetched, pending[0].from)
+			if err := fn(logs, pending[0].to+1); err != nil {
+				return err
+			}
+			pending = pending[1:]
+		}
+	}
+}
+
+func isLimitError(err error) bool {
+	switch status.Code(err) {
+	case codes.InvalidArgument, codes.ResourceExhausted, codes.OutOfRange:
+		return true
+	}
+	msg := strings.ToLower(err.Error())
+	return strings.Contains(msg, "limit") || strings.Contains(msg, "too many") || strings.Contains(msg, "exceed")
+}
@@ -0,0 +1,136 @@
+// Copyright (c) 2020 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package iotex
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/iotexproject/iotex-proto/golang/iotexapi"
+	"github.com/iotexproject/iotex-proto/golang/iotexapi/mock_iotexapi"
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestLogScanner(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// a chain of 5000 heights, dense in 2000~2100, on a node rejecting more than 500 heights or 50 logs
+	const tip = 5000
+	logsAt := func(h uint64) int {
+		switch {
+		case h >= 2000 && h <= 2100:
+			return 3
+		case h%97 == 0:
+			return 1
+		default:
+			return 0
+		}
+	}
+	var (
+		mutex       sync.Mutex
+		concurrency int
+		maxInflight int
+		rejected    int
+	)
+	api := mock_iotexapi.NewMockAPIServiceClient(ctrl)
+	api.EXPECT().GetChainMeta(gomock.Any(), gomock.Any()).Return(&iotexapi.GetChainMetaResponse{
+		ChainMeta: &iotextypes.ChainMeta{Height: tip},
+	}, nil).AnyTimes()
+	api.EXPECT().GetLogs(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, in *iotexapi.GetLogsRequest, _ ...interface{}) (*iotexapi.GetLogsResponse, error) {
+			mutex.Lock()
+			concurrency++
+			if concurrency > maxInflight {
+				maxInflight = concurrency
+			}
+			mutex.Unlock()
+			defer func() {
+				mutex.Lock()
+				concurrency--
+				mutex.Unlock()
+			}()
+			time.Sleep(time.Duration(rand.Intn(2000)) * time.Microsecond)
+
+			from, to := in.GetByRange().GetFromBlock(), in.GetByRange().GetToBlock()
+			if to-from+1 > 500 {
+				mutex.Lock()
+				rejected++
+				mutex.Unlock()
+				return nil, status.Error(codes.InvalidArgument, "range exceeds the limit")
+			}
+			var logs []*iotextypes.Log
+			for h := from; h <= to; h++ {
+				for i := 0; i < logsAt(h); i++ {
+					logs = append(logs, &iotextypes.Log{BlkHeight: h, Index: uint32(i)})
+				}
+			}
+			if len(logs) > 50 {
+				return nil, errors.New("the number of logs exceeds the limit")
+			}
+			return &iotexapi.GetLogsResponse{Logs: logs}, nil
+		}).AnyTimes()
+
+	scanner := NewLogScanner(api, WithChunkSize(100, 2000), WithParallelism(3))
+	var (
+		got  []*iotextypes.Log
+		next = uint64(1)
+	)
+	require.NoError(scanner.Scan(context.Background(), &iotexapi.LogsFilter{}, 1, 0, func(logs []*iotextypes.Log, n uint64) error {
+		for _, l := range logs {
+			require.True(l.BlkHeight >= next && l.BlkHeight < n)
+		}
+		got = append(got, logs...)
+		next = n
+		return nil
+	}))
+	require.EqualValues(tip+1, next)
+	var expected int
+	for h := uint64(1); h <= tip; h++ {
+		expected += logsAt(h)
+	}
+	require.Len(got, expected)
+	for i := 1; i < len(got); i++ {
+		prev, cur := got[i-1], got[i]
+		require.True(prev.BlkHeight < cur.BlkHeight || (prev.BlkHeight == cur.BlkHeight && prev.Index < cur.Index))
+	}
+	require.LessOrEqual(maxInflight, 3)
+	require.Positive(rejected)
+
+	// stop in the middle and resume from the cursor
+	stop := errors.New("stop")
+	var resumed []*iotextypes.Log
+	err := scanner.Scan(context.Background(), &iotexapi.LogsFilter{}, 1, tip, func(logs []*iotextypes.Log, n uint64) error {
+		resumed = append(resumed, logs...)
+		next = n
+		if n > 2050 {
+			return stop
+		}
+		return nil
+	})
+	require.Equal(stop, err)
+	require.NoError(scanner.Scan(context.Background(), &iotexapi.LogsFilter{}, next, tip, func(logs []*iotextypes.Log, n uint64) error {
+		resumed = append(resumed, logs...)
+		return nil
+	}))
+	require.Equal(got, resumed)
+
+	// a single height over the limit fails the scan
+	scanner = NewLogScanner(api, WithLimitError(func(err error) bool { return status.Code(err) == codes.InvalidArgument }))
+	err = scanner.Scan(context.Background(), &iotexapi.LogsFilter{}, 1900, 2200, func([]*iotextypes.Log, uint64) error { return nil })
+	require.Error(err)
+	require.Contains(err.Error(), "exceeds the limit")
+}
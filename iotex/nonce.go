@@ -0,0 +1,145 @@
+// Copyright (c) 2020 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package iotex
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/iotexproject/iotex-address/address"
+	"github.com/iotexproject/iotex-proto/golang/iotexapi"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// NonceManager hands out nonces for actions sent from an account, so that concurrent sends don't collide.
+type NonceManager interface {
+	// Reserve returns the next unused nonce of the address
+	Reserve(ctx context.Context, addr address.Address) (uint64, error)
+	// Release gives back a reserved nonce which was not used, so it can be reserved again
+	Release(addr address.Address, nonce uint64)
+	// Reset makes the next Reserve resync the address from chain, keeping the nonces reserved but not yet sent
+	Reset(addr address.Address)
+}
+
+type (
+	nonceManager struct {
+		api      iotexapi.APIServiceClient
+		mutex    sync.Mutex
+		accounts map[string]*accountNonce
+	}
+
+	accountNonce struct {
+		mutex  sync.Mutex
+		synced bool
+		// next is the nonce after the reserved ones
+		next uint64
+		// released nonces below next, in ascending order
+		gaps []uint64
+	}
+)
+
+// NewNonceManager creates a NonceManager which syncs pending nonces from the API.
+func NewNonceManager(api iotexapi.APIServiceClient) NonceManager {
+	return &nonceManager{
+		api:      api,
+		accounts: make(map[string]*accountNonce),
+	}
+}
+
+func (m *nonceManager) Reserve(ctx context.Context, addr address.Address) (uint64, error) {
+	n := m.account(addr)
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+
+	if !n.synced {
+		res, err := m.api.GetAccount(ctx, &iotexapi.GetAccountRequest{Address: addr.String()})
+		if err != nil {
+			return 0, err
+		}
+		// the pending nonce doesn't count the reserved nonces not yet sent, so next never goes back, and only the
+		// released nonces taken on chain are dropped
+		pending := res.GetAccountMeta().GetPendingNonce()
+		if pending > n.next {
+			n.next = pending
+		}
+		i := sort.Search(len(n.gaps), func(i int) bool { return n.gaps[i] >= pending })
+		n.gaps = n.gaps[i:]
+		n.synced = true
+	}
+	if len(n.gaps) > 0 {
+		nonce := n.gaps[0]
+		n.gaps = n.gaps[1:]
+		return nonce, nil
+	}
+	nonce := n.next
+	n.next++
+	return nonce, nil
+}
+
+func (m *nonceManager) Release(addr address.Address, nonce uint64) {
+	n := m.account(addr)
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+
+	if !n.synced || nonce >= n.next {
+		return
+	}
+	if nonce == n.next-1 {
+		n.next--
+		// the top of the gaps may now be contiguous with next
+		for len(n.gaps) > 0 && n.gaps[len(n.gaps)-1] == n.next-1 {
+			n.gaps = n.gaps[:len(n.gaps)-1]
+			n.next--
+		}
+		return
+	}
+	i := sort.Search(len(n.gaps), func(i int) bool { return n.gaps[i] >= nonce })
+	if i < len(n.gaps) && n.gaps[i] == nonce {
+		return
+	}
+	n.gaps = append(n.gaps, 0)
+	copy(n.gaps[i+1:], n.gaps[i:])
+	n.gaps[i] = nonce
+}
+
+func (m *nonceManager) Reset(addr address.Address) {
+	n := m.account(addr)
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+
+	n.synced = false
+}
+
+func (m *nonceManager) account(addr address.Address) *accountNonce {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	n, ok := m.accounts[addr.String()]
+	if !ok {
+		n = &accountNonce{}
+		m.accounts[addr.String()] = n
+	}
+	return n
+}
+
+// isNonceTooLowError tells whether the action was rejected because its nonce is taken on chain
+func isNonceTooLowError(err error) bool {
+	return strings.Contains(strings.ToLower(err.Error()), "nonce too low")
+}
+
+// isRejectedError tells whether the node rejected the action for sure, so that it is not in the mempool
+func isRejectedError(err error) bool {
+	var s interface{ GRPCStatus() *status.Status }
+	if !errors.As(err, &s) {
+		return false
+	}
+	return s.GRPCStatus().Code() == codes.InvalidArgument
+}
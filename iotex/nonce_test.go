@@ -0,0 +1,205 @@
+// Copyright (c) 2020 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package iotex
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/iotexproject/iotex-address/address"
+	"github.com/iotexproject/iotex-proto/golang/iotexapi"
+	"github.com/iotexproject/iotex-proto/golang/iotexapi/mock_iotexapi"
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/iotexproject/iotex-antenna-go/v2/account"
+)
+
+func pendingNonceResponse(n uint64) *iotexapi.GetAccountResponse {
+	return &iotexapi.GetAccountResponse{AccountMeta: &iotextypes.AccountMeta{PendingNonce: n}}
+}
+
+func TestNonceManager(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	addr, err := address.FromString(_to)
+	require.NoError(err)
+	api := mock_iotexapi.NewMockAPIServiceClient(ctrl)
+	api.EXPECT().GetAccount(gomock.Any(), gomock.Any()).Return(pendingNonceResponse(10), nil).Times(1)
+	m := NewNonceManager(api)
+
+	// concurrent reserves get distinct nonces
+	var (
+		wg     sync.WaitGroup
+		mutex  sync.Mutex
+		nonces = make(map[uint64]bool)
+	)
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			n, err := m.Reserve(context.Background(), addr)
+			require.NoError(err)
+			mutex.Lock()
+			nonces[n] = true
+			mutex.Unlock()
+		}()
+	}
+	wg.Wait()
+	require.Equal(50, len(nonces))
+	for i := uint64(10); i < 60; i++ {
+		require.True(nonces[i])
+	}
+
+	// released nonces are reused lowest first
+	m.Release(addr, 30)
+	m.Release(addr, 20)
+	m.Release(addr, 20)
+	for _, expect := range []uint64{20, 30, 60} {
+		n, err := m.Reserve(context.Background(), addr)
+		require.NoError(err)
+		require.Equal(expect, n)
+	}
+
+	// releasing the top nonce rewinds
+	m.Release(addr, 60)
+	m.Release(addr, 58)
+	m.Release(addr, 59)
+	n, err := m.Reserve(context.Background(), addr)
+	require.NoError(err)
+	require.EqualValues(58, n)
+
+	// reset resyncs from chain
+	m.Reset(addr)
+	api.EXPECT().GetAccount(gomock.Any(), gomock.Any()).Return(pendingNonceResponse(100), nil).Times(1)
+	n, err = m.Reserve(context.Background(), addr)
+	require.NoError(err)
+	require.EqualValues(100, n)
+}
+
+func TestNonceManagerReset(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	addr, err := address.FromString(_to)
+	require.NoError(err)
+	api := mock_iotexapi.NewMockAPIServiceClient(ctrl)
+	// the reserved nonces are not sent yet, so the pending nonce stays
+	pending := uint64(10)
+	api.EXPECT().GetAccount(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(context.Context, *iotexapi.GetAccountRequest, ...grpc.CallOption) (*iotexapi.GetAccountResponse, error) {
+			return pendingNonceResponse(atomic.LoadUint64(&pending)), nil
+		}).AnyTimes()
+	m := NewNonceManager(api)
+
+	// concurrent resets don't hand out the outstanding nonces again
+	var (
+		wg     sync.WaitGroup
+		mutex  sync.Mutex
+		nonces = make(map[uint64]bool)
+	)
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			if i%5 == 0 {
+				m.Reset(addr)
+			}
+			n, err := m.Reserve(context.Background(), addr)
+			require.NoError(err)
+			mutex.Lock()
+			require.False(nonces[n], "nonce %d reserved twice", n)
+			nonces[n] = true
+			mutex.Unlock()
+		}(i)
+	}
+	wg.Wait()
+	require.Equal(50, len(nonces))
+	for i := uint64(10); i < 60; i++ {
+		require.True(nonces[i])
+	}
+
+	// the released nonces are kept unless taken on chain
+	m.Release(addr, 20)
+	m.Reset(addr)
+	n, err := m.Reserve(context.Background(), addr)
+	require.NoError(err)
+	require.EqualValues(20, n)
+	m.Release(addr, 20)
+	m.Release(addr, 30)
+	atomic.StoreUint64(&pending, 25)
+	m.Reset(addr)
+	for _, expect := range []uint64{30, 60} {
+		n, err := m.Reserve(context.Background(), addr)
+		require.NoError(err)
+		require.Equal(expect, n)
+	}
+}
+
+func TestTransferWithNonceManager(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	acc, err := account.HexStringToAccount(_accountPrivateKey)
+	require.NoError(err)
+	to, err := address.FromString(_to)
+	require.NoError(err)
+
+	api := mock_iotexapi.NewMockAPIServiceClient(ctrl)
+	gomock.InOrder(
+		api.EXPECT().GetAccount(gomock.Any(), gomock.Any()).Return(pendingNonceResponse(3), nil),
+		api.EXPECT().SendAction(gomock.Any(), gomock.Any()).Return(nil, errors.New("rpc error: nonce too low")),
+		api.EXPECT().GetAccount(gomock.Any(), gomock.Any()).Return(pendingNonceResponse(5), nil),
+		api.EXPECT().SendAction(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, in *iotexapi.SendActionRequest, _ ...interface{}) (*iotexapi.SendActionResponse, error) {
+				require.EqualValues(5, in.GetAction().GetCore().GetNonce())
+				return &iotexapi.SendActionResponse{
+					ActionHash: "16fbdac39a19f433b32e457eba9c64c48d9fafcdffaddb11a0b7d264c0cf1418",
+				}, nil
+			}),
+		// a rejected send gives the nonce back
+		api.EXPECT().SendAction(gomock.Any(), gomock.Any()).Return(nil, status.Error(codes.InvalidArgument, "insufficient funds")),
+		api.EXPECT().SendAction(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, in *iotexapi.SendActionRequest, _ ...interface{}) (*iotexapi.SendActionResponse, error) {
+				require.EqualValues(6, in.GetAction().GetCore().GetNonce())
+				return &iotexapi.SendActionResponse{
+					ActionHash: "16fbdac39a19f433b32e457eba9c64c48d9fafcdffaddb11a0b7d264c0cf1418",
+				}, nil
+			}),
+		// the action may be in the mempool after a timeout, so the nonce is kept
+		api.EXPECT().SendAction(gomock.Any(), gomock.Any()).Return(nil, status.Error(codes.DeadlineExceeded, "timeout")),
+		api.EXPECT().SendAction(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, in *iotexapi.SendActionRequest, _ ...interface{}) (*iotexapi.SendActionResponse, error) {
+				require.EqualValues(8, in.GetAction().GetCore().GetNonce())
+				return &iotexapi.SendActionResponse{
+					ActionHash: "16fbdac39a19f433b32e457eba9c64c48d9fafcdffaddb11a0b7d264c0cf1418",
+				}, nil
+			}),
+	)
+
+	c := NewAuthedClient(api, 2, acc, WithNonceManager(NewNonceManager(api)))
+	for _, expectErr := range []bool{false, true, false, true, false} {
+		_, err = c.Transfer(to, big.NewInt(1)).SetGasPrice(big.NewInt(1)).SetGasLimit(10000).Call(context.Background())
+		if expectErr {
+			require.Error(err)
+		} else {
+			require.NoError(err)
+		}
+	}
+}
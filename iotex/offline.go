@@ -0,0 +1,234 @@
+// Copyright (c) 2020 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package iotex
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/iotexproject/iotex-address/address"
+	"github.com/iotexproject/iotex-proto/golang/iotexapi"
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	"github.com/iotexproject/iotex-antenna-go/v2/account"
+	"github.com/iotexproject/iotex-antenna-go/v2/errcodes"
+)
+
+// UnsignedActionVersion is the version of the serialized UnsignedAction envelope
+const UnsignedActionVersion = 1
+
+// UnsignedAction is an action with nonce, gas and chain ID filled in, waiting to be signed by the sender.
+// It can be serialized and carried to an offline machine holding the key.
+type UnsignedAction struct {
+	Sender address.Address
+	Core   *iotextypes.ActionCore
+	// Encoding is the encoding to sign the action in
+	Encoding iotextypes.Encoding
+}
+
+type unsignedActionEnvelope struct {
+	Version  uint32          `json:"version"`
+	Sender   string          `json:"sender"`
+	Encoding string          `json:"encoding,omitempty"`
+	Core     json.RawMessage `json:"core"`
+}
+
+// Serialize encodes the unsigned action into a portable JSON envelope
+func (u *UnsignedAction) Serialize() ([]byte, error) {
+	if u.Sender == nil || u.Core == nil {
+		return nil, errcodes.New("sender and action core can not be empty", errcodes.InvalidParam)
+	}
+	core, err := protojson.Marshal(proto.MessageV2(u.Core))
+	if err != nil {
+		return nil, errcodes.NewError(err, errcodes.InternalError)
+	}
+	return json.Marshal(&unsignedActionEnvelope{
+		Version:  UnsignedActionVersion,
+		Sender:   u.Sender.String(),
+		Encoding: u.Encoding.String(),
+		Core:     core,
+	})
+}
+
+// DeserializeUnsignedAction decodes an unsigned action produced by UnsignedAction.Serialize
+func DeserializeUnsignedAction(data []byte) (*UnsignedAction, error) {
+	var env unsignedActionEnvelope
+	if err := json.Unmarshal(data, &env); err != nil {
+		return nil, errcodes.NewError(err, errcodes.InvalidParam)
+	}
+	if env.Version != UnsignedActionVersion {
+		return nil, errcodes.New("unsupported unsigned action version", errcodes.InvalidParam)
+	}
+	sender, err := address.FromString(env.Sender)
+	if err != nil {
+		return nil, errcodes.NewError(err, errcodes.InvalidParam)
+	}
+	encoding := iotextypes.Encoding_IOTEX_PROTOBUF
+	if env.Encoding != "" {
+		v, ok := iotextypes.Encoding_value[env.Encoding]
+		if !ok {
+			return nil, errcodes.New("unsupported encoding "+env.Encoding, errcodes.InvalidParam)
+		}
+		encoding = iotextypes.Encoding(v)
+	}
+	core := &iotextypes.ActionCore{}
+	if err := protojson.Unmarshal(env.Core, proto.MessageV2(core)); err != nil {
+		return nil, errcodes.NewError(err, errcodes.InvalidParam)
+	}
+	return &UnsignedAction{
+		Sender:   sender,
+		Core:     core,
+		Encoding: encoding,
+	}, nil
+}
+
+// Sign signs the unsigned action with the account of the sender
+func (u *UnsignedAction) Sign(a account.Account) (*iotextypes.Action, error) {
+	if a.Address().String() != u.Sender.String() {
+		return nil, errcodes.New("account is not the sender of the action", errcodes.InvalidParam)
+	}
+	sealed, err := signAction(a, u.Core, u.Encoding)
+	if err != nil {
+		return nil, errcodes.NewError(err, errcodes.InternalError)
+	}
+	return sealed, nil
+}
+
+// Build fills in the nonce, gas limit and gas price of the action without signing it. The nonce is the pending
+// nonce of the sender unless set, the nonce manager is not used as the action is not sent by this caller.
+func (c *sendActionCaller) Build(ctx context.Context, opts ...grpc.CallOption) (*UnsignedAction, error) {
+	if c.chainID == 0 {
+		return nil, errcodes.New("0 is not a valid chain ID (use 1 for mainnet, 2 for testnet)", errcodes.InvalidParam)
+	}
+	sender := c.account.Address()
+	core := proto.Clone(c.core).(*iotextypes.ActionCore)
+	core.ChainID = c.chainID
+
+	core.Nonce = c.nonce
+	if core.Nonce == 0 {
+		res, err := c.api.GetAccount(ctx, &iotexapi.GetAccountRequest{Address: sender.String()}, opts...)
+		if err != nil {
+			return nil, errcodes.NewError(err, errcodes.RPCError)
+		}
+		core.Nonce = res.GetAccountMeta().GetPendingNonce()
+	}
+
+	gasPrice := c.gasPrice
+	if gasPrice == nil {
+		response, err := c.api.SuggestGasPrice(ctx, &iotexapi.SuggestGasPriceRequest{}, opts...)
+		if err != nil {
+			return nil, errcodes.NewError(err, errcodes.RPCError)
+		}
+		gasPrice = big.NewInt(0).SetUint64(response.GetGasPrice())
+	}
+	core.GasPrice = gasPrice.String()
+
+	core.GasLimit = c.gasLimit
+	if core.GasLimit == 0 {
+		gas, err := c.estimateGas(ctx, sender, core, opts...)
+		if err != nil {
+			return nil, err
+		}
+		core.GasLimit = gas
+	}
+	return &UnsignedAction{
+		Sender:   sender,
+		Core:     core,
+		Encoding: c.encoding,
+	}, nil
+}
+
+// the intrinsic gas of claiming from and depositing to the rewarding fund, as the node charges
+const (
+	_rewardingBaseGas    = 10000
+	_rewardingGasPerByte = 100
+)
+
+// estimateGas estimates the gas of the action by the sender address, so the key is not needed. The rewarding actions
+// are not executed in the EVM, and cost their intrinsic gas. The other actions not supported by
+// EstimateActionGasConsumption are signed and estimated by EstimateGasForAction.
+func (c *sendActionCaller) estimateGas(ctx context.Context, sender address.Address, core *iotextypes.ActionCore, opts ...grpc.CallOption) (uint64, error) {
+	request := &iotexapi.EstimateActionGasConsumptionRequest{CallerAddress: sender.String()}
+	switch a := core.GetAction().(type) {
+	case *iotextypes.ActionCore_Transfer:
+		request.Action = &iotexapi.EstimateActionGasConsumptionRequest_Transfer{Transfer: a.Transfer}
+	case *iotextypes.ActionCore_Execution:
+		request.Action = &iotexapi.EstimateActionGasConsumptionRequest_Execution{Execution: a.Execution}
+	case *iotextypes.ActionCore_StakeCreate:
+		request.Action = &iotexapi.EstimateActionGasConsumptionRequest_StakeCreate{StakeCreate: a.StakeCreate}
+	case *iotextypes.ActionCore_StakeUnstake:
+		request.Action = &iotexapi.EstimateActionGasConsumptionRequest_StakeUnstake{StakeUnstake: a.StakeUnstake}
+	case *iotextypes.ActionCore_StakeWithdraw:
+		request.Action = &iotexapi.EstimateActionGasConsumptionRequest_StakeWithdraw{StakeWithdraw: a.StakeWithdraw}
+	case *iotextypes.ActionCore_StakeAddDeposit:
+		request.Action = &iotexapi.EstimateActionGasConsumptionRequest_StakeAddDeposit{StakeAddDeposit: a.StakeAddDeposit}
+	case *iotextypes.ActionCore_StakeRestake:
+		request.Action = &iotexapi.EstimateActionGasConsumptionRequest_StakeRestake{StakeRestake: a.StakeRestake}
+	case *iotextypes.ActionCore_StakeChangeCandidate:
+		request.Action = &iotexapi.EstimateActionGasConsumptionRequest_StakeChangeCandidate{StakeChangeCandidate: a.StakeChangeCandidate}
+	case *iotextypes.ActionCore_StakeTransferOwnership:
+		request.Action = &iotexapi.EstimateActionGasConsumptionRequest_StakeTransferOwnership{StakeTransferOwnership: a.StakeTransferOwnership}
+	case *iotextypes.ActionCore_CandidateRegister:
+		request.Action = &iotexapi.EstimateActionGasConsumptionRequest_CandidateRegister{CandidateRegister: a.CandidateRegister}
+	case *iotextypes.ActionCore_CandidateUpdate:
+		request.Action = &iotexapi.EstimateActionGasConsumptionRequest_CandidateUpdate{CandidateUpdate: a.CandidateUpdate}
+	case *iotextypes.ActionCore_ClaimFromRewardingFund:
+		return _rewardingBaseGas + _rewardingGasPerByte*uint64(len(a.ClaimFromRewardingFund.GetData())), nil
+	case *iotextypes.ActionCore_DepositToRewardingFund:
+		return _rewardingBaseGas + _rewardingGasPerByte*uint64(len(a.DepositToRewardingFund.GetData())), nil
+	default:
+		sealed, err := signAction(c.account, core, c.encoding)
+		if err != nil {
+			return 0, errcodes.NewError(err, errcodes.InternalError)
+		}
+		response, err := c.api.EstimateGasForAction(ctx, &iotexapi.EstimateGasForActionRequest{Action: sealed}, opts...)
+		if err != nil {
+			return 0, estimateGasError(c.revertABI, err)
+		}
+		return response.GetGas(), nil
+	}
+	response, err := c.api.EstimateActionGasConsumption(ctx, request, opts...)
+	if err != nil {
+		return 0, estimateGasError(c.revertABI, err)
+	}
+	return response.GetGas(), nil
+}
+
+// BuildAction builds the unsigned action of the caller to be signed offline. The caller must implement Builder, as
+// the send action callers of AuthedClient do.
+func BuildAction(ctx context.Context, c Caller, opts ...grpc.CallOption) (*UnsignedAction, error) {
+	b, ok := c.(Builder)
+	if !ok {
+		return nil, errcodes.New("caller can not build unsigned actions", errcodes.InvalidParam)
+	}
+	return b.Build(ctx, opts...)
+}
+
+func (c *client) Broadcast(ctx context.Context, act *iotextypes.Action, opts ...grpc.CallOption) (hash.Hash256, error) {
+	return broadcast(ctx, c.api, act, opts...)
+}
+
+// broadcast sends the signed action
+func broadcast(ctx context.Context, api iotexapi.APIServiceClient, act *iotextypes.Action, opts ...grpc.CallOption) (hash.Hash256, error) {
+	if act == nil || act.GetCore() == nil {
+		return hash.ZeroHash256, errcodes.New("action can not be empty", errcodes.InvalidParam)
+	}
+	response, err := api.SendAction(ctx, &iotexapi.SendActionRequest{Action: act}, opts...)
+	if err != nil {
+		return hash.ZeroHash256, errcodes.NewError(err, errcodes.RPCError)
+	}
+	h, err := hash.HexStringToHash256(response.GetActionHash())
+	if err != nil {
+		return hash.ZeroHash256, errcodes.NewError(err, errcodes.BadResponse)
+	}
+	return h, nil
+}
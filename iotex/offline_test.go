@@ -0,0 +1,148 @@
+// Copyright (c) 2020 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package iotex
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/golang/protobuf/proto"
+	"github.com/iotexproject/iotex-address/address"
+	"github.com/iotexproject/iotex-proto/golang/iotexapi"
+	"github.com/iotexproject/iotex-proto/golang/iotexapi/mock_iotexapi"
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-antenna-go/v2/account"
+	"github.com/iotexproject/iotex-antenna-go/v2/errcodes"
+)
+
+var (
+	_ SendActionCaller      = (*MockSendActionCaller)(nil)
+	_ ClaimRewardCaller     = (*MockClaimRewardCaller)(nil)
+	_ ExecuteContractCaller = (*MockExecuteContractCaller)(nil)
+	_ DeployContractCaller  = (*MockDeployContractCaller)(nil)
+	_ ReadOnlyClient        = (*MockReadOnlyClient)(nil)
+
+	_ Builder     = (*transferCaller)(nil)
+	_ Builder     = (*claimRewardCaller)(nil)
+	_ Builder     = (*executeContractCaller)(nil)
+	_ Builder     = (*deployContractCaller)(nil)
+	_ Builder     = (*stakingCaller)(nil)
+	_ Broadcaster = (*client)(nil)
+)
+
+func TestOfflineTransfer(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	acc, err := account.HexStringToAccount(_accountPrivateKey)
+	require.NoError(err)
+	to, err := address.FromString(_to)
+	require.NoError(err)
+
+	// build on the online machine, without the key
+	api := mock_iotexapi.NewMockAPIServiceClient(ctrl)
+	api.EXPECT().GetAccount(gomock.Any(), gomock.Any()).Return(&iotexapi.GetAccountResponse{
+		AccountMeta: &iotextypes.AccountMeta{PendingNonce: 5},
+	}, nil)
+	api.EXPECT().SuggestGasPrice(gomock.Any(), gomock.Any()).Return(&iotexapi.SuggestGasPriceResponse{GasPrice: 1000000000000}, nil)
+	api.EXPECT().EstimateActionGasConsumption(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, in *iotexapi.EstimateActionGasConsumptionRequest, _ ...interface{}) (*iotexapi.EstimateActionGasConsumptionResponse, error) {
+			require.Equal(acc.Address().String(), in.GetCallerAddress())
+			require.Equal(to.String(), in.GetTransfer().GetRecipient())
+			return &iotexapi.EstimateActionGasConsumptionResponse{Gas: 10000}, nil
+		})
+	online := NewAuthedClient(api, 2, account.AddressToAccount(acc.Address()))
+	unsigned, err := BuildAction(context.Background(), online.Transfer(to, big.NewInt(100)).SetPayload([]byte("memo")))
+	require.NoError(err)
+	require.EqualValues(5, unsigned.Core.GetNonce())
+	require.EqualValues(10000, unsigned.Core.GetGasLimit())
+	require.Equal("1000000000000", unsigned.Core.GetGasPrice())
+	require.EqualValues(2, unsigned.Core.GetChainID())
+	data, err := unsigned.Serialize()
+	require.NoError(err)
+
+	// sign on the offline machine
+	unsigned, err = DeserializeUnsignedAction(data)
+	require.NoError(err)
+	require.Equal(acc.Address().String(), unsigned.Sender.String())
+	other, err := account.NewAccount()
+	require.NoError(err)
+	_, err = unsigned.Sign(other)
+	require.Error(err)
+	sealed, err := unsigned.Sign(acc)
+	require.NoError(err)
+	require.Equal(acc.PublicKey().Bytes(), sealed.GetSenderPubKey())
+	msg, err := proto.Marshal(sealed.GetCore())
+	require.NoError(err)
+	require.True(acc.Verify(msg, sealed.GetSignature()))
+
+	// broadcast on the online machine
+	h, err := ActionHash(sealed, 0)
+	require.NoError(err)
+	api.EXPECT().SendAction(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, in *iotexapi.SendActionRequest, _ ...interface{}) (*iotexapi.SendActionResponse, error) {
+			require.True(proto.Equal(sealed, in.GetAction()))
+			return &iotexapi.SendActionResponse{ActionHash: hex.EncodeToString(h[:])}, nil
+		})
+	sent, err := NewReadOnlyClient(api).(Broadcaster).Broadcast(context.Background(), sealed)
+	require.NoError(err)
+	require.Equal(h, sent)
+}
+
+func TestOfflineBuild(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	acc, err := account.HexStringToAccount(_accountPrivateKey)
+	require.NoError(err)
+	api := mock_iotexapi.NewMockAPIServiceClient(ctrl)
+	online := NewAuthedClient(api, 2, account.AddressToAccount(acc.Address()))
+
+	// all set, no API call is needed
+	unsigned, err := BuildAction(context.Background(), online.Staking().Create("robotbp00000", big.NewInt(100), 7, true).
+		SetNonce(3).SetGasLimit(20000).SetGasPrice(big.NewInt(1)))
+	require.NoError(err)
+	require.Equal("robotbp00000", unsigned.Core.GetStakeCreate().GetCandidateName())
+	require.EqualValues(3, unsigned.Core.GetNonce())
+
+	// the gas of claim reward is its intrinsic gas
+	unsigned, err = BuildAction(context.Background(), online.ClaimReward(big.NewInt(1)).SetData([]byte("claim")).
+		SetNonce(3).SetGasPrice(big.NewInt(1)))
+	require.NoError(err)
+	require.EqualValues(10500, unsigned.Core.GetGasLimit())
+	require.Equal("1", unsigned.Core.GetClaimFromRewardingFund().GetAmount())
+	_, err = BuildAction(context.Background(), online.ClaimReward(big.NewInt(1)).SetNonce(3).SetGasPrice(big.NewInt(1)).SetGasLimit(10000))
+	require.NoError(err)
+	// the other actions are estimated by a signed action, which needs the key
+	caller := online.(*authedClient).newSendActionCaller()
+	_, err = caller.estimateGas(context.Background(), acc.Address(), &iotextypes.ActionCore{
+		Action: &iotextypes.ActionCore_GrantReward{GrantReward: &iotextypes.GrantReward{}},
+	})
+	require.Error(err)
+	require.Equal(errcodes.InternalError, err.(errcodes.ErrorWithCode).Code())
+	require.True(errors.Is(err, account.ErrWatchOnly))
+
+	// the deploy data is the same when built twice
+	deploy := online.DeployContract([]byte{1, 2, 3}).SetNonce(3).SetGasPrice(big.NewInt(1)).SetGasLimit(10000)
+	for i := 0; i < 2; i++ {
+		unsigned, err = BuildAction(context.Background(), deploy)
+		require.NoError(err)
+		require.Equal([]byte{1, 2, 3}, unsigned.Core.GetExecution().GetData())
+	}
+
+	// the callers of other implementations may not build
+	_, err = BuildAction(context.Background(), NewMockSendActionCaller(ctrl))
+	require.Error(err)
+}
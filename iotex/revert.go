@@ -0,0 +1,176 @@
+// Copyright (c) 2020 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package iotex
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+	"google.golang.org/grpc/status"
+
+	"github.com/iotexproject/iotex-antenna-go/v2/errcodes"
+)
+
+// names of the errors built into solidity
+const (
+	RevertErrorName = "Error"
+	RevertPanicName = "Panic"
+)
+
+// the messages of the gas estimation of the node, when the simulated execution fails
+const (
+	_estimateRevertedPrefix = "execution simulation is reverted due to the reason: "
+	_estimateFailedPrefix   = "execution simulation failed: status = "
+)
+
+var (
+	_revertSelector = crypto.Keccak256([]byte("Error(string)"))[:4]
+	_panicSelector  = crypto.Keccak256([]byte("Panic(uint256)"))[:4]
+)
+
+// RevertError is the error of a failed contract execution, with the revert reason decoded.
+type RevertError struct {
+	// Status is the receipt status of the execution
+	Status iotextypes.ReceiptStatus
+	// Name is "Error" for a revert reason string, "Panic" for a panic code, or the name of the custom error
+	// declared in the contract ABI. It is empty if the revert data cannot be decoded.
+	Name string
+	// Reason is the revert reason string, or the description of the panic code
+	Reason string
+	// Args are the decoded arguments of the error
+	Args []interface{}
+	// Data is the raw revert data
+	Data []byte
+}
+
+func (e *RevertError) Error() string {
+	switch {
+	case e.Name == RevertErrorName || e.Name == RevertPanicName:
+		return "execution reverted: " + e.Reason
+	case e.Name != "":
+		args := make([]string, len(e.Args))
+		for i, arg := range e.Args {
+			args[i] = fmt.Sprint(arg)
+		}
+		return fmt.Sprintf("execution reverted: %s(%s)", e.Name, strings.Join(args, ", "))
+	case len(e.Data) > 0:
+		return fmt.Sprintf("execution reverted: 0x%x", e.Data)
+	default:
+		return fmt.Sprintf("execution failed with status %d (%s)", e.Status, e.Status)
+	}
+}
+
+// DecodeRevert decodes the revert data of a failed execution. Error(string) and Panic(uint256) are always
+// decoded, custom errors are decoded if they are declared in contractABI, which can be nil.
+func DecodeRevert(contractABI *abi.ABI, status iotextypes.ReceiptStatus, data []byte) *RevertError {
+	e := &RevertError{
+		Status: status,
+		Data:   data,
+	}
+	if len(data) < 4 {
+		return e
+	}
+	switch {
+	case bytes.Equal(data[:4], _revertSelector):
+		reason, err := abi.UnpackRevert(data)
+		if err != nil {
+			return e
+		}
+		e.Name, e.Reason, e.Args = RevertErrorName, reason, []interface{}{reason}
+	case bytes.Equal(data[:4], _panicSelector):
+		reason, err := abi.UnpackRevert(data)
+		if err != nil || len(data) != 36 {
+			return e
+		}
+		e.Name, e.Reason, e.Args = RevertPanicName, reason, []interface{}{new(big.Int).SetBytes(data[4:])}
+	case contractABI != nil:
+		var selector [4]byte
+		copy(selector[:], data[:4])
+		abiErr, err := contractABI.ErrorByID(selector)
+		if err != nil {
+			return e
+		}
+		args, err := abiErr.Inputs.Unpack(data[4:])
+		if err != nil {
+			return e
+		}
+		e.Name, e.Args = abiErr.Name, args
+	}
+	return e
+}
+
+// ReceiptRevertError returns the RevertError of a failed receipt, or nil if the receipt is successful.
+// contractABI is used to decode custom errors, and can be nil.
+func ReceiptRevertError(contractABI *abi.ABI, r *iotextypes.Receipt) *RevertError {
+	status := iotextypes.ReceiptStatus(r.GetStatus())
+	if status == iotextypes.ReceiptStatus_Success {
+		return nil
+	}
+	msg := r.GetExecutionRevertMsg()
+	if data, ok := revertData(contractABI, msg); ok {
+		return DecodeRevert(contractABI, status, data)
+	}
+	e := &RevertError{Status: status}
+	if msg != "" {
+		// the node has decoded the reason string
+		e.Name, e.Reason, e.Args = RevertErrorName, msg, []interface{}{msg}
+	}
+	return e
+}
+
+// revertData returns the raw revert data in the revert message of the receipt. The node decodes the reason string of
+// Error(string), and writes the other revert data in hex without 0x prefix, which is taken as data only if it starts
+// with a known selector, so that a reason string looking like hex is kept.
+func revertData(contractABI *abi.ABI, msg string) ([]byte, bool) {
+	if strings.HasPrefix(msg, "0x") {
+		data, err := hex.DecodeString(msg[2:])
+		return data, err == nil
+	}
+	data, err := hex.DecodeString(msg)
+	if err != nil || len(data) < 4 {
+		return nil, false
+	}
+	if bytes.Equal(data[:4], _revertSelector) || bytes.Equal(data[:4], _panicSelector) {
+		return data, true
+	}
+	if contractABI == nil {
+		return nil, false
+	}
+	var selector [4]byte
+	copy(selector[:], data[:4])
+	_, err = contractABI.ErrorByID(selector)
+	return data, err == nil
+}
+
+// estimateGasError returns the error of the gas estimation, with the RevertError of the simulated execution decoded
+func estimateGasError(contractABI *abi.ABI, err error) error {
+	s, ok := status.FromError(err)
+	if !ok {
+		return errcodes.NewError(err, errcodes.RPCError)
+	}
+	msg := s.Message()
+	switch {
+	case strings.HasPrefix(msg, _estimateRevertedPrefix):
+		return errcodes.NewError(ReceiptRevertError(contractABI, &iotextypes.Receipt{
+			Status:             uint64(iotextypes.ReceiptStatus_ErrExecutionReverted),
+			ExecutionRevertMsg: strings.TrimPrefix(msg, _estimateRevertedPrefix),
+		}), errcodes.ExecutionFailed)
+	case strings.HasPrefix(msg, _estimateFailedPrefix):
+		code, err := strconv.ParseUint(strings.TrimPrefix(msg, _estimateFailedPrefix), 10, 64)
+		if err == nil && code != uint64(iotextypes.ReceiptStatus_Success) {
+			return errcodes.NewError(&RevertError{Status: iotextypes.ReceiptStatus(code)}, errcodes.ExecutionFailed)
+		}
+	}
+	return errcodes.NewError(err, errcodes.RPCError)
+}
@@ -0,0 +1,166 @@
+// Copyright (c) 2020 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package iotex
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/golang/mock/gomock"
+	"github.com/iotexproject/iotex-address/address"
+	"github.com/iotexproject/iotex-proto/golang/iotexapi"
+	"github.com/iotexproject/iotex-proto/golang/iotexapi/mock_iotexapi"
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/iotexproject/iotex-antenna-go/v2/account"
+	"github.com/iotexproject/iotex-antenna-go/v2/errcodes"
+)
+
+const _customErrorABI = `[{"inputs":[{"name":"available","type":"uint256"},{"name":"required","type":"uint256"}],"name":"InsufficientBalance","type":"error"},{"inputs":[{"name":"x","type":"uint256"}],"name":"set","outputs":[],"stateMutability":"nonpayable","type":"function"}]`
+
+func TestDecodeRevert(t *testing.T) {
+	require := require.New(t)
+
+	parsed, err := abi.JSON(strings.NewReader(_customErrorABI))
+	require.NoError(err)
+	reason := "ERC20: transfer amount exceeds balance"
+	errorData, err := abi.Arguments{{Type: mustType("string")}}.Pack(reason)
+	require.NoError(err)
+	errorData = append(append([]byte{}, _revertSelector...), errorData...)
+	panicData, err := abi.Arguments{{Type: mustType("uint256")}}.Pack(big.NewInt(0x11))
+	require.NoError(err)
+	panicData = append(append([]byte{}, _panicSelector...), panicData...)
+	customErr := parsed.Errors["InsufficientBalance"]
+	customData, err := customErr.Inputs.Pack(big.NewInt(100), big.NewInt(200))
+	require.NoError(err)
+	customData = append(customErr.ID[:4], customData...)
+
+	status := iotextypes.ReceiptStatus_ErrExecutionReverted
+	for _, v := range []struct {
+		abi    *abi.ABI
+		data   []byte
+		name   string
+		args   []interface{}
+		errStr string
+	}{
+		{nil, errorData, RevertErrorName, []interface{}{reason}, "execution reverted: " + reason},
+		{nil, panicData, RevertPanicName, []interface{}{big.NewInt(0x11)}, "execution reverted: arithmetic underflow or overflow"},
+		{&parsed, customData, "InsufficientBalance", []interface{}{big.NewInt(100), big.NewInt(200)}, "execution reverted: InsufficientBalance(100, 200)"},
+		{nil, customData, "", nil, "execution reverted: 0x" + hex.EncodeToString(customData)},
+		{nil, nil, "", nil, "execution failed with status 106 (ErrExecutionReverted)"},
+	} {
+		e := DecodeRevert(v.abi, status, v.data)
+		require.Equal(v.name, e.Name)
+		require.Equal(v.args, e.Args)
+		require.Equal(v.errStr, e.Error())
+	}
+
+	// receipt with reason string decoded by the node
+	e := ReceiptRevertError(nil, &iotextypes.Receipt{Status: uint64(status), ExecutionRevertMsg: reason})
+	require.Equal(RevertErrorName, e.Name)
+	require.Equal(reason, e.Reason)
+	// receipt with raw revert data
+	e = ReceiptRevertError(&parsed, &iotextypes.Receipt{Status: uint64(status), ExecutionRevertMsg: "0x" + hex.EncodeToString(customData)})
+	require.Equal("InsufficientBalance", e.Name)
+	// receipt with raw revert data in hex without 0x prefix, as the node writes
+	e = ReceiptRevertError(&parsed, &iotextypes.Receipt{Status: uint64(status), ExecutionRevertMsg: hex.EncodeToString(customData)})
+	require.Equal("InsufficientBalance", e.Name)
+	require.Equal(customData, e.Data)
+	e = ReceiptRevertError(nil, &iotextypes.Receipt{Status: uint64(status), ExecutionRevertMsg: hex.EncodeToString(panicData)})
+	require.Equal(RevertPanicName, e.Name)
+	require.Equal([]interface{}{big.NewInt(0x11)}, e.Args)
+	// a reason string looking like hex
+	e = ReceiptRevertError(nil, &iotextypes.Receipt{Status: uint64(status), ExecutionRevertMsg: "deadbeef"})
+	require.Equal(RevertErrorName, e.Name)
+	require.Equal("deadbeef", e.Reason)
+	require.Nil(ReceiptRevertError(nil, &iotextypes.Receipt{Status: uint64(iotextypes.ReceiptStatus_Success)}))
+}
+
+func TestReadContractRevert(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	parsed, err := abi.JSON(strings.NewReader(_customErrorABI))
+	require.NoError(err)
+	customErr := parsed.Errors["InsufficientBalance"]
+	customData, err := customErr.Inputs.Pack(big.NewInt(1), big.NewInt(2))
+	require.NoError(err)
+	customData = append(customErr.ID[:4], customData...)
+
+	api := mock_iotexapi.NewMockAPIServiceClient(ctrl)
+	api.EXPECT().ReadContract(gomock.Any(), gomock.Any()).Return(&iotexapi.ReadContractResponse{
+		Data:    hex.EncodeToString(customData),
+		Receipt: &iotextypes.Receipt{Status: uint64(iotextypes.ReceiptStatus_ErrExecutionReverted)},
+	}, nil)
+	contract, err := address.FromString("io17sn486alutrnzlrdz9vv44g7qyc38hygf7s6h0")
+	require.NoError(err)
+
+	_, err = NewReadOnlyClient(api).ReadOnlyContract(contract, parsed).Read("set", big.NewInt(1)).Call(context.Background())
+	var revertErr *RevertError
+	require.True(errors.As(err, &revertErr))
+	require.Equal("InsufficientBalance", revertErr.Name)
+	require.Equal([]interface{}{big.NewInt(1), big.NewInt(2)}, revertErr.Args)
+}
+
+func TestExecuteContractRevert(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	parsed, err := abi.JSON(strings.NewReader(_customErrorABI))
+	require.NoError(err)
+	customErr := parsed.Errors["InsufficientBalance"]
+	customData, err := customErr.Inputs.Pack(big.NewInt(1), big.NewInt(2))
+	require.NoError(err)
+	customData = append(customErr.ID[:4], customData...)
+	acc, err := account.NewAccount()
+	require.NoError(err)
+	contract, err := address.FromString("io17sn486alutrnzlrdz9vv44g7qyc38hygf7s6h0")
+	require.NoError(err)
+
+	api := mock_iotexapi.NewMockAPIServiceClient(ctrl)
+	c := NewAuthedClient(api, 1, acc).Contract(contract, parsed)
+	for _, v := range []struct {
+		err    error
+		name   string
+		status iotextypes.ReceiptStatus
+	}{
+		// the revert messages of the node
+		{status.Error(codes.Internal, "execution simulation is reverted due to the reason: "+hex.EncodeToString(customData)), "InsufficientBalance", iotextypes.ReceiptStatus_ErrExecutionReverted},
+		{status.Error(codes.Internal, "execution simulation is reverted due to the reason: not owner"), RevertErrorName, iotextypes.ReceiptStatus_ErrExecutionReverted},
+		{status.Error(codes.Internal, "execution simulation failed: status = 101"), "", iotextypes.ReceiptStatus_ErrOutOfGas},
+	} {
+		api.EXPECT().EstimateGasForAction(gomock.Any(), gomock.Any()).Return(nil, v.err)
+		_, err = c.Execute("set", big.NewInt(1)).SetNonce(1).SetGasPrice(big.NewInt(1)).Call(context.Background())
+		var revertErr *RevertError
+		require.True(errors.As(err, &revertErr))
+		require.Equal(v.name, revertErr.Name)
+		require.Equal(v.status, revertErr.Status)
+		require.Equal(errcodes.ExecutionFailed, err.(errcodes.ErrorWithCode).Code())
+	}
+	// the other errors are not reverts
+	api.EXPECT().EstimateGasForAction(gomock.Any(), gomock.Any()).Return(nil, status.Error(codes.Unavailable, "connection refused"))
+	_, err = c.Execute("set", big.NewInt(1)).SetNonce(1).SetGasPrice(big.NewInt(1)).Call(context.Background())
+	require.Equal(errcodes.RPCError, err.(errcodes.ErrorWithCode).Code())
+}
+
+func mustType(t string) abi.Type {
+	typ, err := abi.NewType(t, "", nil)
+	if err != nil {
+		panic(err)
+	}
+	return typ
+}
@@ -0,0 +1,269 @@
+// Copyright (c) 2020 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package iotex
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/cenkalti/backoff"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/event"
+	"github.com/iotexproject/iotex-proto/golang/iotexapi"
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/iotexproject/iotex-antenna-go/v2/errcodes"
+)
+
+// the max number of blocks of a GetRawBlocks call
+const _rawBlocksBatch = 100
+
+type (
+	// SubscribeOption is an option of SubscribeBlocks and SubscribeLogs
+	SubscribeOption func(*subscribeConfig)
+
+	subscribeConfig struct {
+		start         uint64
+		confirmations uint64
+		minBackoff    time.Duration
+		maxBackoff    time.Duration
+	}
+
+	// follower follows the tip of the chain by StreamBlocks, and delivers the confirmed heights in order. It
+	// reconnects on errors, and resumes from the height after the last delivered one.
+	follower struct {
+		api iotexapi.APIServiceClient
+		cfg subscribeConfig
+		// next is the next height to deliver
+		next uint64
+		// deliver delivers the heights from next to height, and advances next. tip is the block of the height from
+		// the stream, or nil.
+		deliver func(ctx context.Context, height uint64, tip *iotexapi.BlockInfo) error
+	}
+)
+
+// WithStartHeight makes the subscription start from the height, such as the one after the last height handled before
+// a restart. The default is the height after the confirmed tip when subscribing.
+func WithStartHeight(h uint64) SubscribeOption {
+	return func(c *subscribeConfig) {
+		c.start = h
+	}
+}
+
+// WithConfirmations makes the subscription deliver a height only when n blocks are produced on top of it
+func WithConfirmations(n uint64) SubscribeOption {
+	return func(c *subscribeConfig) {
+		c.confirmations = n
+	}
+}
+
+// WithReconnectBackoff sets the min and the max time to wait before reconnecting, default are 1 second and 1 minute.
+// The wait doubles on each failed reconnection.
+func WithReconnectBackoff(min, max time.Duration) SubscribeOption {
+	return func(c *subscribeConfig) {
+		c.minBackoff, c.maxBackoff = min, max
+	}
+}
+
+func (c *client) SubscribeBlocks(ctx context.Context, opts ...SubscribeOption) (<-chan *iotexapi.BlockInfo, ethereum.Subscription, error) {
+	f, err := newFollower(ctx, c.api, opts...)
+	if err != nil {
+		return nil, nil, err
+	}
+	ch := make(chan *iotexapi.BlockInfo)
+	f.deliver = func(ctx context.Context, height uint64, tip *iotexapi.BlockInfo) error {
+		for f.next <= height {
+			var blocks []*iotexapi.BlockInfo
+			if tip != nil && f.next == height {
+				blocks = []*iotexapi.BlockInfo{tip}
+			} else {
+				count := height - f.next + 1
+				if count > _rawBlocksBatch {
+					count = _rawBlocksBatch
+				}
+				response, err := f.api.GetRawBlocks(ctx, &iotexapi.GetRawBlocksRequest{
+					StartHeight:  f.next,
+					Count:        count,
+					WithReceipts: true,
+				})
+				if err != nil {
+					return err
+				}
+				if blocks = response.GetBlocks(); len(blocks) == 0 {
+					return fmt.Errorf("no block at height %d", f.next)
+				}
+			}
+			for _, b := range blocks {
+				if h := b.GetBlock().GetHeader().GetCore().GetHeight(); h != f.next {
+					return fmt.Errorf("block at height %d, expecting %d", h, f.next)
+				}
+				select {
+				case ch <- b:
+				case <-ctx.Done():
+					return ctx.Err()
+				}
+				f.next++
+			}
+		}
+		return nil
+	}
+	return ch, event.NewSubscription(func(quit <-chan struct{}) error {
+		defer close(ch)
+		return f.run(ctx, quit)
+	}), nil
+}
+
+func (c *client) SubscribeLogs(ctx context.Context, filter *iotexapi.LogsFilter, opts ...SubscribeOption) (<-chan *iotextypes.Log, ethereum.Subscription, error) {
+	f, err := newFollower(ctx, c.api, opts...)
+	if err != nil {
+		return nil, nil, err
+	}
+	ch := make(chan *iotextypes.Log)
+	scanner := NewLogScanner(c.api)
+	f.deliver = func(ctx context.Context, height uint64, _ *iotexapi.BlockInfo) error {
+		return scanner.Scan(ctx, filter, f.next, height, func(logs []*iotextypes.Log, next uint64) error {
+			for _, l := range logs {
+				select {
+				case ch <- l:
+				case <-ctx.Done():
+					return ctx.Err()
+				}
+			}
+			f.next = next
+			return nil
+		})
+	}
+	return ch, event.NewSubscription(func(quit <-chan struct{}) error {
+		defer close(ch)
+		return f.run(ctx, quit)
+	}), nil
+}
+
+func newFollower(ctx context.Context, api iotexapi.APIServiceClient, opts ...SubscribeOption) (*follower, error) {
+	f := &follower{
+		api: api,
+		cfg: subscribeConfig{
+			minBackoff: time.Second,
+			maxBackoff: time.Minute,
+		},
+	}
+	for _, opt := range opts {
+		opt(&f.cfg)
+	}
+	f.next = f.cfg.start
+	if f.next == 0 {
+		response, err := api.GetChainMeta(ctx, &iotexapi.GetChainMetaRequest{})
+		if err != nil {
+			return nil, errcodes.NewError(err, errcodes.RPCError)
+		}
+		f.next = 1
+		if tip := response.GetChainMeta().GetHeight(); tip > f.cfg.confirmations {
+			f.next = tip - f.cfg.confirmations + 1
+		}
+	}
+	return f, nil
+}
+
+// run follows the chain until ctx is done, quit is closed, or a permanent error
+func (f *follower) run(ctx context.Context, quit <-chan struct{}) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	go func() {
+		select {
+		case <-quit:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	b := backoff.NewExponentialBackOff()
+	b.InitialInterval = f.cfg.minBackoff
+	b.MaxInterval = f.cfg.maxBackoff
+	b.Multiplier = 2
+	b.MaxElapsedTime = 0
+	b.Reset()
+	for {
+		progressed, err := f.follow(ctx)
+		select {
+		case <-quit:
+			return nil
+		default:
+		}
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if isPermanentError(err) {
+			return errcodes.NewError(err, errcodes.RPCError)
+		}
+		if progressed {
+			b.Reset()
+		}
+		timer := time.NewTimer(b.NextBackOff())
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+		case <-timer.C:
+		}
+	}
+}
+
+// follow streams the blocks and delivers the confirmed heights, until the stream breaks. It returns whether any
+// block is received from the stream.
+func (f *follower) follow(ctx context.Context) (bool, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	// stream before getting the tip, so no block is missed in between
+	stream, err := f.api.StreamBlocks(ctx, &iotexapi.StreamBlocksRequest{})
+	if err != nil {
+		return false, err
+	}
+	response, err := f.api.GetChainMeta(ctx, &iotexapi.GetChainMetaRequest{})
+	if err != nil {
+		return false, err
+	}
+	if err := f.advance(ctx, response.GetChainMeta().GetHeight(), nil); err != nil {
+		return false, err
+	}
+	for progressed := false; ; progressed = true {
+		res, err := stream.Recv()
+		if err != nil {
+			return progressed, err
+		}
+		if err := f.advance(ctx, res.GetBlock().GetBlock().GetHeader().GetCore().GetHeight(), res.GetBlock()); err != nil {
+			return true, err
+		}
+	}
+}
+
+// advance delivers the heights confirmed by the tip
+func (f *follower) advance(ctx context.Context, tip uint64, block *iotexapi.BlockInfo) error {
+	if tip < f.cfg.confirmations || tip-f.cfg.confirmations < f.next {
+		return nil
+	}
+	if f.cfg.confirmations > 0 {
+		block = nil
+	}
+	return f.deliver(ctx, tip-f.cfg.confirmations, block)
+}
+
+// isPermanentError tells the error won't go away by reconnecting
+func isPermanentError(err error) bool {
+	var s interface{ GRPCStatus() *status.Status }
+	if !errors.As(err, &s) {
+		return false
+	}
+	switch s.GRPCStatus().Code() {
+	case codes.InvalidArgument, codes.Unimplemented, codes.PermissionDenied, codes.Unauthenticated:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,192 @@
+// Copyright (c) 2020 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package iotex
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/iotexproject/iotex-proto/golang/iotexapi"
+	"github.com/iotexproject/iotex-proto/golang/iotexapi/mock_iotexapi"
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var _ Subscriber = (*client)(nil)
+
+// testChain is a chain whose tip grows as the blocks are streamed
+type testChain struct {
+	tip uint64
+}
+
+type testBlockStream struct {
+	grpc.ClientStream
+	ctx     context.Context
+	chain   *testChain
+	heights []uint64
+	err     error
+}
+
+func (s *testBlockStream) Recv() (*iotexapi.StreamBlocksResponse, error) {
+	if len(s.heights) == 0 {
+		if s.err != nil {
+			return nil, s.err
+		}
+		<-s.ctx.Done()
+		return nil, s.ctx.Err()
+	}
+	h := s.heights[0]
+	s.heights = s.heights[1:]
+	atomic.StoreUint64(&s.chain.tip, h)
+	return &iotexapi.StreamBlocksResponse{Block: testBlock(h)}, nil
+}
+
+func testBlock(h uint64) *iotexapi.BlockInfo {
+	return &iotexapi.BlockInfo{Block: &iotextypes.Block{
+		Header: &iotextypes.BlockHeader{Core: &iotextypes.BlockHeaderCore{Height: h}},
+	}}
+}
+
+func newTestChainAPI(ctrl *gomock.Controller, chain *testChain, streams ...func(ctx context.Context) *testBlockStream) *mock_iotexapi.MockAPIServiceClient {
+	api := mock_iotexapi.NewMockAPIServiceClient(ctrl)
+	api.EXPECT().GetChainMeta(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(context.Context, *iotexapi.GetChainMetaRequest, ...grpc.CallOption) (*iotexapi.GetChainMetaResponse, error) {
+			return &iotexapi.GetChainMetaResponse{
+				ChainMeta: &iotextypes.ChainMeta{Height: atomic.LoadUint64(&chain.tip)},
+			}, nil
+		}).AnyTimes()
+	api.EXPECT().GetRawBlocks(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, in *iotexapi.GetRawBlocksRequest, _ ...grpc.CallOption) (*iotexapi.GetRawBlocksResponse, error) {
+			var blocks []*iotexapi.BlockInfo
+			for h := in.GetStartHeight(); h < in.GetStartHeight()+in.GetCount() && h <= atomic.LoadUint64(&chain.tip); h++ {
+				blocks = append(blocks, testBlock(h))
+			}
+			return &iotexapi.GetRawBlocksResponse{Blocks: blocks}, nil
+		}).AnyTimes()
+	api.EXPECT().GetLogs(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, in *iotexapi.GetLogsRequest, _ ...grpc.CallOption) (*iotexapi.GetLogsResponse, error) {
+			var logs []*iotextypes.Log
+			for h := in.GetByRange().GetFromBlock(); h <= in.GetByRange().GetToBlock(); h++ {
+				if h%2 == 0 {
+					logs = append(logs, &iotextypes.Log{BlkHeight: h})
+				}
+			}
+			return &iotexapi.GetLogsResponse{Logs: logs}, nil
+		}).AnyTimes()
+	var calls int32
+	api.EXPECT().StreamBlocks(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, _ *iotexapi.StreamBlocksRequest, _ ...grpc.CallOption) (iotexapi.APIService_StreamBlocksClient, error) {
+			i := int(atomic.AddInt32(&calls, 1)) - 1
+			if i >= len(streams) {
+				return &testBlockStream{ctx: ctx, chain: chain}, nil
+			}
+			return streams[i](ctx), nil
+		}).AnyTimes()
+	return api
+}
+
+func TestSubscribeBlocks(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	chain := &testChain{tip: 10}
+	api := newTestChainAPI(ctrl, chain,
+		func(ctx context.Context) *testBlockStream {
+			return &testBlockStream{ctx: ctx, chain: chain, heights: []uint64{11, 12}, err: status.Error(codes.Unavailable, "disconnected")}
+		},
+		func(ctx context.Context) *testBlockStream {
+			// blocks produced while disconnected
+			atomic.StoreUint64(&chain.tip, 15)
+			return &testBlockStream{ctx: ctx, chain: chain, heights: []uint64{16, 17}}
+		},
+	)
+
+	c := NewReadOnlyClient(api).(Subscriber)
+	blocks, sub, err := c.SubscribeBlocks(context.Background(), WithStartHeight(5), WithConfirmations(2),
+		WithReconnectBackoff(time.Millisecond, 10*time.Millisecond))
+	require.NoError(err)
+	next := uint64(5)
+	for next <= 15 {
+		select {
+		case b := <-blocks:
+			require.Equal(next, b.GetBlock().GetHeader().GetCore().GetHeight())
+			next++
+		case err := <-sub.Err():
+			require.NoError(err)
+		case <-time.After(5 * time.Second):
+			require.FailNow("timeout waiting for block", "height %d", next)
+		}
+	}
+	sub.Unsubscribe()
+	for range blocks {
+		// drained until closed
+	}
+
+	// the subscription ends on a permanent error
+	api = newTestChainAPI(ctrl, chain, func(ctx context.Context) *testBlockStream {
+		return &testBlockStream{ctx: ctx, chain: chain, err: status.Error(codes.Unauthenticated, "no token")}
+	})
+	blocks, sub, err = NewReadOnlyClient(api).(Subscriber).SubscribeBlocks(context.Background())
+	require.NoError(err)
+	select {
+	case err := <-sub.Err():
+		require.Equal(codes.Unauthenticated, status.Code(err.(interface{ Cause() error }).Cause()))
+	case <-time.After(5 * time.Second):
+		require.FailNow("timeout waiting for error")
+	}
+	_, ok := <-blocks
+	require.False(ok)
+}
+
+func TestSubscribeLogs(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	chain := &testChain{tip: 10}
+	api := newTestChainAPI(ctrl, chain,
+		func(ctx context.Context) *testBlockStream {
+			return &testBlockStream{ctx: ctx, chain: chain, heights: []uint64{11, 12, 13}, err: status.Error(codes.Unavailable, "disconnected")}
+		},
+		func(ctx context.Context) *testBlockStream {
+			atomic.StoreUint64(&chain.tip, 16)
+			return &testBlockStream{ctx: ctx, chain: chain, heights: []uint64{16, 17, 18}}
+		},
+	)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	logs, sub, err := NewReadOnlyClient(api).(Subscriber).SubscribeLogs(ctx, &iotexapi.LogsFilter{}, WithConfirmations(0),
+		WithReconnectBackoff(time.Millisecond, 10*time.Millisecond))
+	require.NoError(err)
+	// starts after the tip when subscribing
+	next := uint64(12)
+	for next <= 18 {
+		select {
+		case l := <-logs:
+			require.Equal(next, l.GetBlkHeight())
+			next += 2
+		case <-time.After(5 * time.Second):
+			require.FailNow("timeout waiting for log", "height %d", next)
+		}
+	}
+	cancel()
+	select {
+	case err := <-sub.Err():
+		require.Equal(context.Canceled, err)
+	case <-time.After(5 * time.Second):
+		require.FailNow("timeout waiting for error")
+	}
+	_, ok := <-logs
+	require.False(ok)
+}
@@ -2,8 +2,11 @@ package iotex
 
 import (
 	"fmt"
+	"math/big"
 
+	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/golang/protobuf/proto"
+	"github.com/iotexproject/go-pkgs/crypto"
 	"github.com/iotexproject/go-pkgs/hash"
 	"github.com/iotexproject/iotex-proto/golang/iotextypes"
 
@@ -26,6 +29,18 @@ func sign(a account.Account, act *iotextypes.ActionCore) (*iotextypes.Action, er
 	}, nil
 }
 
+// signAction signs the action core in the given encoding
+func signAction(a account.Account, act *iotextypes.ActionCore, encoding iotextypes.Encoding) (*iotextypes.Action, error) {
+	switch encoding {
+	case iotextypes.Encoding_IOTEX_PROTOBUF:
+		return sign(a, act)
+	case iotextypes.Encoding_ETHEREUM_RLP:
+		return signRLP(a, act, EVMNetworkID(act.GetChainID()))
+	default:
+		return nil, fmt.Errorf("invalid encoding type = %v", encoding)
+	}
+}
+
 // ActionHash computes the hash of an action
 func ActionHash(act *iotextypes.Action, chainid uint32) (hash.Hash256, error) {
 	switch act.Encoding {
@@ -49,3 +64,35 @@ func ActionHash(act *iotextypes.Action, chainid uint32) (hash.Hash256, error) {
 		return hash.ZeroHash256, fmt.Errorf("invalid encoding type = %v", act.Encoding)
 	}
 }
+
+// VerifyAction verifies the signature of the action against its sender public key. evmChainID is used to verify
+// actions in Encoding_ETHEREUM_RLP.
+func VerifyAction(act *iotextypes.Action, evmChainID uint32) error {
+	pk, err := crypto.BytesToPublicKey(act.GetSenderPubKey())
+	if err != nil {
+		return err
+	}
+	var h []byte
+	switch act.GetEncoding() {
+	case iotextypes.Encoding_IOTEX_PROTOBUF:
+		msg, err := proto.Marshal(act.GetCore())
+		if err != nil {
+			return err
+		}
+		digest := hash.Hash256b(msg)
+		h = digest[:]
+	case iotextypes.Encoding_ETHEREUM_RLP:
+		tx, err := actionToRLP(act.GetCore())
+		if err != nil {
+			return err
+		}
+		digest := types.NewEIP155Signer(big.NewInt(int64(evmChainID))).Hash(tx)
+		h = digest[:]
+	default:
+		return fmt.Errorf("invalid encoding type = %v", act.GetEncoding())
+	}
+	if !pk.Verify(h, act.GetSignature()) {
+		return fmt.Errorf("invalid signature of action")
+	}
+	return nil
+}
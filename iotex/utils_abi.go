@@ -0,0 +1,405 @@
+// Copyright (c) 2020 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package iotex
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"reflect"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/iotexproject/iotex-address/address"
+)
+
+var _bigIntType = reflect.TypeOf(&big.Int{})
+
+// encodeValue converts the value to the Go type abi.Pack expects for the ABI type, recursively. It converts
+//   - io1 and 0x address strings and address.Address to common.Address
+//   - Go structs, maps keyed by the component names and slices in the component order to tuples
+//   - hex strings and byte arrays to bytes and bytesN
+//   - decimal or 0x hex strings and any integers to the integer types, in range
+//   - the values of the named bool and string types to bool and string
+//
+// The values of the other types are passed through, to be checked by abi.Pack.
+func encodeValue(t abi.Type, v interface{}, name string) (interface{}, error) {
+	if v == nil {
+		return nil, fmt.Errorf("argument %s: nil value", name)
+	}
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Ptr && !rv.IsNil() && rv.Type() != _bigIntType {
+		rv = rv.Elem()
+	}
+	switch t.T {
+	case abi.AddressTy:
+		addr, err := addressTypeAssert(v)
+		if err != nil {
+			return nil, fmt.Errorf("argument %s: %v", name, err)
+		}
+		return addr, nil
+	case abi.IntTy, abi.UintTy:
+		return encodeInteger(t, rv, name)
+	case abi.FixedBytesTy:
+		return encodeFixedBytes(t, rv, name)
+	case abi.BytesTy:
+		if s, ok := v.(string); ok {
+			b, err := decodeHexString(s)
+			if err != nil {
+				return nil, fmt.Errorf("argument %s: %v", name, err)
+			}
+			return b, nil
+		}
+		if (rv.Kind() == reflect.Slice || rv.Kind() == reflect.Array) && rv.Type().Elem().Kind() == reflect.Uint8 {
+			b := make([]byte, rv.Len())
+			reflect.Copy(reflect.ValueOf(b), rv)
+			return b, nil
+		}
+		return nil, fmt.Errorf("argument %s: %s is not bytes", name, rv.Type())
+	case abi.BoolTy, abi.StringTy:
+		out := reflect.New(t.GetType()).Elem()
+		if err := setValue(out, rv.Interface(), name); err != nil {
+			return nil, err
+		}
+		return out.Interface(), nil
+	case abi.SliceTy, abi.ArrayTy:
+		if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+			return nil, fmt.Errorf("argument %s: %T is not a slice or an array", name, v)
+		}
+		var out reflect.Value
+		if t.T == abi.SliceTy {
+			out = reflect.MakeSlice(t.GetType(), rv.Len(), rv.Len())
+		} else {
+			if rv.Len() != t.Size {
+				return nil, fmt.Errorf("argument %s: %d elements, expecting %d", name, rv.Len(), t.Size)
+			}
+			out = reflect.New(t.GetType()).Elem()
+		}
+		for i := 0; i < rv.Len(); i++ {
+			elem, err := encodeValue(*t.Elem, rv.Index(i).Interface(), fmt.Sprintf("%s[%d]", name, i))
+			if err != nil {
+				return nil, err
+			}
+			if err := setValue(out.Index(i), elem, fmt.Sprintf("%s[%d]", name, i)); err != nil {
+				return nil, err
+			}
+		}
+		return out.Interface(), nil
+	case abi.TupleTy:
+		return encodeTuple(t, rv, name)
+	default:
+		return v, nil
+	}
+}
+
+func encodeInteger(t abi.Type, rv reflect.Value, name string) (interface{}, error) {
+	n := new(big.Int)
+	switch rv.Kind() {
+	case reflect.String:
+		if _, ok := n.SetString(rv.String(), 0); !ok {
+			return nil, fmt.Errorf("argument %s: invalid integer %q", name, rv.String())
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n.SetInt64(rv.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		n.SetUint64(rv.Uint())
+	default:
+		switch v := rv.Interface().(type) {
+		case *big.Int:
+			if v == nil {
+				return nil, fmt.Errorf("argument %s: nil integer", name)
+			}
+			n.Set(v)
+		case big.Int:
+			n.Set(&v)
+		default:
+			return nil, fmt.Errorf("argument %s: %T is not an integer", name, v)
+		}
+	}
+	var min, max *big.Int
+	if t.T == abi.UintTy {
+		min, max = new(big.Int), new(big.Int).Lsh(big.NewInt(1), uint(t.Size))
+	} else {
+		max = new(big.Int).Lsh(big.NewInt(1), uint(t.Size-1))
+		min = new(big.Int).Neg(max)
+	}
+	if n.Cmp(min) < 0 || n.Cmp(max) >= 0 {
+		return nil, fmt.Errorf("argument %s: %s is out of the range of %s", name, n, t)
+	}
+	if typ := t.GetType(); typ != _bigIntType {
+		if t.T == abi.UintTy {
+			return reflect.ValueOf(n.Uint64()).Convert(typ).Interface(), nil
+		}
+		return reflect.ValueOf(n.Int64()).Convert(typ).Interface(), nil
+	}
+	return n, nil
+}
+
+func encodeFixedBytes(t abi.Type, rv reflect.Value, name string) (interface{}, error) {
+	var b []byte
+	switch {
+	case rv.Kind() == reflect.String:
+		var err error
+		if b, err = decodeHexString(rv.String()); err != nil {
+			return nil, fmt.Errorf("argument %s: %v", name, err)
+		}
+	case (rv.Kind() == reflect.Slice || rv.Kind() == reflect.Array) && rv.Type().Elem().Kind() == reflect.Uint8:
+		b = make([]byte, rv.Len())
+		reflect.Copy(reflect.ValueOf(b), rv)
+	default:
+		return nil, fmt.Errorf("argument %s: %s is not bytes", name, rv.Type())
+	}
+	if len(b) != t.Size {
+		return nil, fmt.Errorf("argument %s: %d bytes, expecting %d", name, len(b), t.Size)
+	}
+	out := reflect.New(t.GetType()).Elem()
+	reflect.Copy(out, reflect.ValueOf(b))
+	return out.Interface(), nil
+}
+
+func encodeTuple(t abi.Type, rv reflect.Value, name string) (interface{}, error) {
+	out := reflect.New(t.GetType()).Elem()
+	for i, raw := range t.TupleRawNames {
+		var field reflect.Value
+		switch rv.Kind() {
+		case reflect.Struct:
+			field = structField(rv, raw)
+		case reflect.Map:
+			if rv.Type().Key().Kind() != reflect.String {
+				return nil, fmt.Errorf("argument %s: map keys are not strings", name)
+			}
+			field = rv.MapIndex(reflect.ValueOf(raw).Convert(rv.Type().Key()))
+		case reflect.Slice, reflect.Array:
+			if rv.Len() != len(t.TupleElems) {
+				return nil, fmt.Errorf("argument %s: %d components, expecting %d", name, rv.Len(), len(t.TupleElems))
+			}
+			field = rv.Index(i)
+		default:
+			return nil, fmt.Errorf("argument %s: %s is not a struct, a map or a slice", name, rv.Kind())
+		}
+		if !field.IsValid() {
+			return nil, fmt.Errorf("argument %s: missing component %s", name, raw)
+		}
+		elem, err := encodeValue(*t.TupleElems[i], field.Interface(), name+"."+raw)
+		if err != nil {
+			return nil, err
+		}
+		if err := setValue(out.Field(i), elem, name+"."+raw); err != nil {
+			return nil, err
+		}
+	}
+	return out.Interface(), nil
+}
+
+// setValue sets the value to dst, converting it only between the types of the same kind, as int to string is
+// convertible but not expected
+func setValue(dst reflect.Value, v interface{}, name string) error {
+	rv := reflect.ValueOf(v)
+	switch {
+	case !rv.IsValid():
+		return fmt.Errorf("argument %s: nil value", name)
+	case rv.Type().AssignableTo(dst.Type()):
+		dst.Set(rv)
+	case rv.Kind() == dst.Kind() && rv.Type().ConvertibleTo(dst.Type()):
+		dst.Set(rv.Convert(dst.Type()))
+	default:
+		return fmt.Errorf("argument %s: %s is not %s", name, rv.Type(), dst.Type())
+	}
+	return nil
+}
+
+// structField returns the field of the struct for the tuple component, by the abi tag, the camel case name or the
+// name case-insensitively
+func structField(rv reflect.Value, raw string) reflect.Value {
+	camel := abi.ToCamelCase(raw)
+	for _, match := range []func(f reflect.StructField) bool{
+		func(f reflect.StructField) bool { return f.Tag.Get("abi") == raw },
+		func(f reflect.StructField) bool { return f.Name == camel },
+		func(f reflect.StructField) bool { return strings.EqualFold(f.Name, raw) },
+	} {
+		for i := 0; i < rv.NumField(); i++ {
+			if f := rv.Type().Field(i); f.IsExported() && match(f) {
+				return rv.Field(i)
+			}
+		}
+	}
+	return reflect.Value{}
+}
+
+func decodeHexString(s string) ([]byte, error) {
+	if !strings.HasPrefix(s, "0x") && !strings.HasPrefix(s, "0X") {
+		return nil, fmt.Errorf("hex string %q without 0x prefix", s)
+	}
+	return hex.DecodeString(s[2:])
+}
+
+var (
+	_ethAddressType = reflect.TypeOf(common.Address{})
+	_ioAddressType  = reflect.TypeOf((*address.Address)(nil)).Elem()
+)
+
+// decodeOutputs sets the unpacked values of the outputs to dst
+func decodeOutputs(outputs abi.Arguments, values []interface{}, dst reflect.Value) error {
+	switch {
+	case len(outputs) == 0:
+		return nil
+	case len(outputs) == 1 && (outputs[0].Type.T == abi.TupleTy || dst.Kind() != reflect.Struct):
+		return decodeValue(dst, reflect.ValueOf(values[0]))
+	}
+	switch dst.Kind() {
+	case reflect.Struct:
+		for i, output := range outputs {
+			field := structField(dst, output.Name)
+			if !field.IsValid() {
+				if len(outputs) > 1 || dst.NumField() == 0 {
+					return fmt.Errorf("output %s can't be found in %s", output.Name, dst.Type())
+				}
+				// a single output goes into the first field, as abi.Arguments.Copy
+				field = dst.Field(0)
+			}
+			if err := decodeValue(field, reflect.ValueOf(values[i])); err != nil {
+				return fmt.Errorf("output %s: %v", output.Name, err)
+			}
+		}
+		return nil
+	case reflect.Slice, reflect.Array:
+		if dst.Kind() == reflect.Slice && dst.Len() < len(values) {
+			dst.Set(reflect.MakeSlice(dst.Type(), len(values), len(values)))
+		}
+		if dst.Len() < len(values) {
+			return fmt.Errorf("%d outputs, more than the length %d", len(values), dst.Len())
+		}
+		for i := range values {
+			if err := decodeValue(dst.Index(i), reflect.ValueOf(values[i])); err != nil {
+				return fmt.Errorf("output %s: %v", outputs[i].Name, err)
+			}
+		}
+		return nil
+	default:
+		return fmt.Errorf("cannot unmarshal %d outputs into %s", len(outputs), dst.Type())
+	}
+}
+
+// decodeValue sets the unpacked value src to dst, converting the addresses and the tuples
+func decodeValue(dst, src reflect.Value) error {
+	if dst.Kind() == reflect.Ptr && dst.Type() != _bigIntType {
+		if dst.IsNil() {
+			dst.Set(reflect.New(dst.Type().Elem()))
+		}
+		return decodeValue(dst.Elem(), src)
+	}
+	if dst.Kind() == reflect.Interface && dst.NumMethod() == 0 {
+		v, err := ioAddressValue(src)
+		if err != nil {
+			return err
+		}
+		dst.Set(v)
+		return nil
+	}
+	if src.Type() == _ethAddressType {
+		switch {
+		case dst.Type() == _ioAddressType:
+			addr, err := address.FromBytes(src.Interface().(common.Address).Bytes())
+			if err != nil {
+				return err
+			}
+			dst.Set(reflect.ValueOf(addr))
+			return nil
+		case dst.Kind() == reflect.String:
+			addr, err := address.FromBytes(src.Interface().(common.Address).Bytes())
+			if err != nil {
+				return err
+			}
+			dst.SetString(addr.String())
+			return nil
+		}
+	}
+	switch {
+	case src.Type().AssignableTo(dst.Type()):
+		dst.Set(src)
+	case src.Kind() == reflect.Struct && dst.Kind() == reflect.Struct:
+		for i := 0; i < src.NumField(); i++ {
+			name := src.Type().Field(i).Tag.Get("json")
+			if name == "" {
+				name = src.Type().Field(i).Name
+			}
+			field := structField(dst, name)
+			if !field.IsValid() {
+				return fmt.Errorf("component %s can't be found in %s", name, dst.Type())
+			}
+			if err := decodeValue(field, src.Field(i)); err != nil {
+				return fmt.Errorf("component %s: %v", name, err)
+			}
+		}
+	case (src.Kind() == reflect.Slice || src.Kind() == reflect.Array) && dst.Kind() == reflect.Slice:
+		s := reflect.MakeSlice(dst.Type(), src.Len(), src.Len())
+		for i := 0; i < src.Len(); i++ {
+			if err := decodeValue(s.Index(i), src.Index(i)); err != nil {
+				return err
+			}
+		}
+		dst.Set(s)
+	case (src.Kind() == reflect.Slice || src.Kind() == reflect.Array) && dst.Kind() == reflect.Array:
+		if src.Len() != dst.Len() {
+			return fmt.Errorf("cannot unmarshal %d elements into %s", src.Len(), dst.Type())
+		}
+		for i := 0; i < src.Len(); i++ {
+			if err := decodeValue(dst.Index(i), src.Index(i)); err != nil {
+				return err
+			}
+		}
+	default:
+		return fmt.Errorf("cannot unmarshal %s into %s", src.Type(), dst.Type())
+	}
+	return nil
+}
+
+// ioAddressValue converts common.Address to address.Address, including the ones in slices and arrays. The other values,
+// including the tuples, are returned as they are.
+func ioAddressValue(src reflect.Value) (reflect.Value, error) {
+	switch {
+	case src.Type() == _ethAddressType:
+		addr, err := address.FromBytes(src.Interface().(common.Address).Bytes())
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		return reflect.ValueOf(&addr).Elem(), nil
+	case (src.Kind() == reflect.Slice || src.Kind() == reflect.Array) && hasEthAddress(src.Type()):
+		s := reflect.MakeSlice(reflect.SliceOf(ioAddressType(src.Type().Elem())), src.Len(), src.Len())
+		for i := 0; i < src.Len(); i++ {
+			v, err := ioAddressValue(src.Index(i))
+			if err != nil {
+				return reflect.Value{}, err
+			}
+			s.Index(i).Set(v)
+		}
+		return s, nil
+	default:
+		return src, nil
+	}
+}
+
+func hasEthAddress(t reflect.Type) bool {
+	// common.Address is an array itself
+	for t != _ethAddressType && (t.Kind() == reflect.Slice || t.Kind() == reflect.Array) {
+		t = t.Elem()
+	}
+	return t == _ethAddressType
+}
+
+// ioAddressType returns the type with common.Address replaced by address.Address, and the arrays by slices
+func ioAddressType(t reflect.Type) reflect.Type {
+	switch {
+	case t == _ethAddressType:
+		return _ioAddressType
+	case t.Kind() == reflect.Slice || t.Kind() == reflect.Array:
+		return reflect.SliceOf(ioAddressType(t.Elem()))
+	default:
+		return t
+	}
+}
@@ -0,0 +1,307 @@
+// Copyright (c) 2020 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package iotex
+
+import (
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/iotexproject/iotex-address/address"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-antenna-go/v2/errcodes"
+)
+
+const _structABI = `[
+	{
+		"inputs": [
+			{
+				"components": [
+					{"name": "recipient", "type": "address"},
+					{"name": "amount", "type": "uint256"},
+					{"name": "id", "type": "bytes32"},
+					{
+						"components": [
+							{"name": "signer", "type": "address"},
+							{"name": "deadline", "type": "uint64"}
+						],
+						"name": "permit",
+						"type": "tuple"
+					}
+				],
+				"name": "orders",
+				"type": "tuple[]"
+			},
+			{"name": "guardians", "type": "address[2]"},
+			{"name": "groups", "type": "address[][]"},
+			{"name": "tag", "type": "bytes4"},
+			{"name": "delta", "type": "int8"}
+		],
+		"name": "submit",
+		"outputs": [],
+		"stateMutability": "nonpayable",
+		"type": "function"
+	},
+	{
+		"inputs": [
+			{"name": "flags", "type": "bool[]"},
+			{
+				"components": [
+					{"name": "text", "type": "string"}
+				],
+				"name": "note",
+				"type": "tuple"
+			},
+			{"name": "blobs", "type": "bytes[]"}
+		],
+		"name": "label",
+		"outputs": [],
+		"stateMutability": "nonpayable",
+		"type": "function"
+	}
+]`
+
+func TestEncodeArgument(t *testing.T) {
+	r := require.New(t)
+
+	contractABI, err := abi.JSON(strings.NewReader(_structABI))
+	r.NoError(err)
+	method := contractABI.Methods["submit"]
+
+	io1 := "io18jaldgzc8wlyfnzamgas62yu3kg5nw527czg37"
+	addr1, err := address.FromString(io1)
+	r.NoError(err)
+	eth1 := common.BytesToAddress(addr1.Bytes())
+	addr2, err := address.FromString("io1ntprz4p5zw38fvtfrcczjtcv3rkr3nqs6sm3pj")
+	r.NoError(err)
+	eth2 := common.BytesToAddress(addr2.Bytes())
+	id := common.HexToHash("0x0102030405060708091011121314151617181920212223242526272829303132")
+	amount, ok := new(big.Int).SetString("1000000000000000000000", 10)
+	r.True(ok)
+
+	// the expected encoding, packed with the Go types of go-ethereum
+	type permit struct {
+		Signer   common.Address
+		Deadline uint64
+	}
+	type order struct {
+		Recipient common.Address
+		Amount    *big.Int
+		Id        [32]byte
+		Permit    permit
+	}
+	expected, err := contractABI.Pack("submit",
+		[]order{{eth1, amount, id, permit{eth2, 1700000000}}, {eth2, big.NewInt(1), id, permit{eth1, 0}}},
+		[2]common.Address{eth1, eth2},
+		[][]common.Address{{eth1}, {eth1, eth2}},
+		[4]byte{0xde, 0xad, 0xbe, 0xef},
+		int8(-5),
+	)
+	r.NoError(err)
+
+	// structs with io addresses and the map form of tuples
+	type userPermit struct {
+		Who      string `abi:"signer"`
+		Deadline int
+	}
+	type userOrder struct {
+		Recipient address.Address
+		Amount    string
+		ID        string
+		Permit    *userPermit
+	}
+	args, err := encodeArgument(method, []interface{}{
+		[]interface{}{
+			userOrder{addr1, "1000000000000000000000", id.Hex(), &userPermit{addr2.String(), 1700000000}},
+			map[string]interface{}{
+				"recipient": "0x" + strings.ToLower(eth2.Hex()[2:]),
+				"amount":    1,
+				"id":        id,
+				"permit":    []interface{}{io1, uint64(0)},
+			},
+		},
+		[]string{io1, eth2.Hex()},
+		[][]address.Address{{addr1}, {addr1, addr2}},
+		"0xdeadbeef",
+		"-5",
+	})
+	r.NoError(err)
+	packed, err := contractABI.Pack("submit", args...)
+	r.NoError(err)
+	r.Equal(expected, packed)
+
+	// errors tell the argument
+	for _, v := range []struct {
+		args []interface{}
+		err  string
+	}{
+		{[]interface{}{[]interface{}{}, []string{io1}, [][]string{}, "0xdeadbeef", 1}, "argument guardians: 1 elements, expecting 2"},
+		{[]interface{}{[]interface{}{}, []string{io1, io1}, [][]string{{"io1x"}}, "0xdeadbeef", 1}, "argument groups[0][0]"},
+		{[]interface{}{[]interface{}{}, []string{io1, io1}, [][]string{}, "0xdead", 1}, "argument tag: 2 bytes, expecting 4"},
+		{[]interface{}{[]interface{}{}, []string{io1, io1}, [][]string{}, "0xdeadbeef", 128}, "argument delta: 128 is out of the range of int8"},
+		{[]interface{}{[]interface{}{map[string]interface{}{"recipient": io1}}, []string{io1, io1}, [][]string{}, "0xdeadbeef", 1}, "argument orders[0]: missing component amount"},
+		{[]interface{}{[]interface{}{map[string]interface{}{"recipient": io1, "amount": "-1"}}, []string{io1, io1}, [][]string{}, "0xdeadbeef", 1}, "argument orders[0].amount: -1 is out of the range of uint256"},
+	} {
+		_, err := encodeArgument(method, v.args)
+		r.Error(err)
+		r.Contains(err.Error(), v.err)
+	}
+
+	// the elements and the components of the wrong Go types
+	type flag bool
+	method = contractABI.Methods["label"]
+	expected, err = contractABI.Pack("label", []bool{true}, struct{ Text string }{"hi"}, [][]byte{{1, 2, 3, 4}})
+	r.NoError(err)
+	args, err = encodeArgument(method, []interface{}{[]flag{true}, []interface{}{"hi"}, [][4]byte{{1, 2, 3, 4}}})
+	r.NoError(err)
+	packed, err = contractABI.Pack("label", args...)
+	r.NoError(err)
+	r.Equal(expected, packed)
+	for _, v := range []struct {
+		args []interface{}
+		err  string
+	}{
+		{[]interface{}{[]interface{}{1}, []interface{}{"hi"}, [][]byte{}}, "argument flags[0]: int is not bool"},
+		{[]interface{}{[]bool{}, []interface{}{5}, [][]byte{}}, "argument note.text: int is not string"},
+		{[]interface{}{[]bool{}, []interface{}{"hi"}, []interface{}{5}}, "argument blobs[0]: int is not bytes"},
+		{[]interface{}{[]bool{}, []interface{}{"hi"}, []interface{}{nil}}, "argument blobs[0]: nil value"},
+	} {
+		_, err := encodeArgument(method, v.args)
+		r.Error(err)
+		r.Contains(err.Error(), v.err)
+		r.Equal(errcodes.InvalidParam, err.(errcodes.ErrorWithCode).Code())
+	}
+}
+
+const _outputsABI = `[
+	{
+		"inputs": [],
+		"name": "info",
+		"outputs": [
+			{"name": "owner", "type": "address"},
+			{"name": "balance", "type": "uint256"},
+			{"name": "members", "type": "address[]"},
+			{
+				"components": [
+					{"name": "recipient", "type": "address"},
+					{"name": "deadline", "type": "uint64"}
+				],
+				"name": "permit",
+				"type": "tuple"
+			}
+		],
+		"stateMutability": "view",
+		"type": "function"
+	},
+	{
+		"inputs": [],
+		"name": "permit",
+		"outputs": [
+			{
+				"components": [
+					{"name": "recipient", "type": "address"},
+					{"name": "deadline", "type": "uint64"}
+				],
+				"name": "",
+				"type": "tuple"
+			}
+		],
+		"stateMutability": "view",
+		"type": "function"
+	},
+	{
+		"inputs": [],
+		"name": "owner",
+		"outputs": [{"name": "", "type": "address"}],
+		"stateMutability": "view",
+		"type": "function"
+	}
+]`
+
+func TestDataUnmarshalInto(t *testing.T) {
+	r := require.New(t)
+
+	contractABI, err := abi.JSON(strings.NewReader(_outputsABI))
+	r.NoError(err)
+	addr1, err := address.FromString("io18jaldgzc8wlyfnzamgas62yu3kg5nw527czg37")
+	r.NoError(err)
+	eth1 := common.BytesToAddress(addr1.Bytes())
+	addr2, err := address.FromString("io1ntprz4p5zw38fvtfrcczjtcv3rkr3nqs6sm3pj")
+	r.NoError(err)
+	eth2 := common.BytesToAddress(addr2.Bytes())
+	type permit struct {
+		Recipient common.Address
+		Deadline  uint64
+	}
+	raw, err := contractABI.Methods["info"].Outputs.Pack(eth1, big.NewInt(100), []common.Address{eth1, eth2}, permit{eth2, 1700000000})
+	r.NoError(err)
+	data := Data{method: "info", abi: &contractABI, Raw: raw}
+
+	// named outputs into a struct, tuples into structs and addresses into io addresses
+	type userPermit struct {
+		Recipient address.Address
+		Deadline  uint64
+	}
+	var info struct {
+		Owner   address.Address
+		Balance *big.Int
+		Members []string
+		Permit  *userPermit
+	}
+	r.NoError(data.UnmarshalInto(&info))
+	r.Equal(addr1.String(), info.Owner.String())
+	r.Equal(big.NewInt(100), info.Balance)
+	r.Equal([]string{addr1.String(), addr2.String()}, info.Members)
+	r.Equal(addr2.String(), info.Permit.Recipient.String())
+	r.EqualValues(1700000000, info.Permit.Deadline)
+
+	// the outputs in order
+	var values [4]interface{}
+	r.NoError(data.UnmarshalInto(&values))
+	r.Equal(addr1.String(), values[0].(address.Address).String())
+	r.Len(values[2], 2)
+
+	// the map form
+	m := make(map[string]interface{})
+	r.NoError(data.UnmarshalIntoMap(m))
+	r.Equal(addr1.String(), m["owner"].(address.Address).String())
+	r.Equal(big.NewInt(100), m["balance"])
+	r.Equal(addr2.String(), m["members"].([]address.Address)[1].String())
+
+	// a single tuple output into a struct, and a single address
+	raw, err = contractABI.Methods["permit"].Outputs.Pack(permit{eth1, 1})
+	r.NoError(err)
+	var p userPermit
+	r.NoError(Data{method: "permit", abi: &contractABI, Raw: raw}.UnmarshalInto(&p))
+	r.Equal(addr1.String(), p.Recipient.String())
+	r.EqualValues(1, p.Deadline)
+
+	raw, err = contractABI.Methods["owner"].Outputs.Pack(eth2)
+	r.NoError(err)
+	var owner address.Address
+	r.NoError(Data{method: "owner", abi: &contractABI, Raw: raw}.UnmarshalInto(&owner))
+	r.Equal(addr2.String(), owner.String())
+	var ethOwner common.Address
+	r.NoError(Data{method: "owner", abi: &contractABI, Raw: raw}.UnmarshalInto(&ethOwner))
+	r.Equal(eth2, ethOwner)
+	// the unnamed output by its position
+	m = make(map[string]interface{})
+	r.NoError(Data{method: "owner", abi: &contractABI, Raw: raw}.UnmarshalIntoMap(m))
+	r.Equal(map[string]interface{}{"0": owner}, m)
+	r.Error(data.UnmarshalIntoMap(nil))
+
+	// errors
+	r.Error(data.UnmarshalInto(info))
+	var wrong struct {
+		Owner   address.Address
+		Balance string
+	}
+	r.Error(data.UnmarshalInto(&wrong))
+}
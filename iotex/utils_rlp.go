@@ -7,13 +7,46 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/iotexproject/go-pkgs/crypto"
 	"github.com/iotexproject/go-pkgs/hash"
 	"github.com/iotexproject/iotex-address/address"
 	"github.com/iotexproject/iotex-proto/golang/iotextypes"
 	"golang.org/x/crypto/sha3"
+
+	"github.com/iotexproject/iotex-antenna-go/v2/account"
+)
+
+// EVM network IDs used as EIP-155 chain ID by IoTeX mainnet and testnet
+const (
+	MainnetEVMNetworkID = 4689
+	TestnetEVMNetworkID = 4690
 )
 
+// EVMNetworkID returns the EIP-155 chain ID of the given IoTeX chain ID (1 for mainnet, 2 for testnet),
+// other chain IDs are returned as is
+func EVMNetworkID(chainID uint32) uint32 {
+	switch chainID {
+	case 1:
+		return MainnetEVMNetworkID
+	case 2:
+		return TestnetEVMNetworkID
+	default:
+		return chainID
+	}
+}
+
 func actionToRLP(core *iotextypes.ActionCore) (*types.Transaction, error) {
+	gasPrice := new(big.Int)
+	gasPrice.SetString(core.GetGasPrice(), 10)
+	// staking and rewarding actions are calls to the system contracts
+	sysContract, data, ok, err := nativeActionToRLP(core)
+	if err != nil {
+		return nil, err
+	}
+	if ok {
+		return types.NewTransaction(core.GetNonce(), sysContract, big.NewInt(0), core.GetGasLimit(), gasPrice, data), nil
+	}
+
 	var (
 		to      string
 		amount  = new(big.Int)
@@ -36,8 +69,6 @@ func actionToRLP(core *iotextypes.ActionCore) (*types.Transaction, error) {
 	}
 
 	// generate raw tx
-	gasPrice := new(big.Int)
-	gasPrice.SetString(core.GetGasPrice(), 10)
 	if to != "" {
 		addr, err := address.FromString(to)
 		if err != nil {
@@ -49,6 +80,34 @@ func actionToRLP(core *iotextypes.ActionCore) (*types.Transaction, error) {
 	return types.NewContractCreation(core.GetNonce(), amount, core.GetGasLimit(), gasPrice, payload), nil
 }
 
+// signRLP signs the action core as an EIP-155 Ethereum transaction, so the action hash is the same as the
+// transaction hash seen by Ethereum tools
+func signRLP(a account.Account, act *iotextypes.ActionCore, evmChainID uint32) (*iotextypes.Action, error) {
+	tx, err := actionToRLP(act)
+	if err != nil {
+		return nil, err
+	}
+	s, err := account.AsSigner(a)
+	if err != nil {
+		return nil, err
+	}
+	h := types.NewEIP155Signer(big.NewInt(int64(evmChainID))).Hash(tx)
+	sig, err := account.SignHash(s, h[:])
+	if err != nil {
+		return nil, err
+	}
+	// signature in [R || S || V] format where V = 27 + recovery id
+	if sig[64] < 27 {
+		sig[64] += 27
+	}
+	return &iotextypes.Action{
+		Core:         act,
+		SenderPubKey: a.PublicKey().Bytes(),
+		Signature:    sig,
+		Encoding:     iotextypes.Encoding_ETHEREUM_RLP,
+	}, nil
+}
+
 func rlpSignedHash(tx *types.Transaction, chainID uint32, sig []byte) (hash.Hash256, error) {
 	if len(sig) != 65 {
 		return hash.ZeroHash256, fmt.Errorf("invalid signature length = %d, expecting 65", len(sig))
@@ -67,3 +126,83 @@ func rlpSignedHash(tx *types.Transaction, chainID uint32, sig []byte) (hash.Hash
 	rlp.Encode(h, signedTx)
 	return hash.BytesToHash256(h.Sum(nil)), nil
 }
+
+// EthTxToAction converts a signed legacy EIP-155 Ethereum transaction into an action in Encoding_ETHEREUM_RLP, with
+// the sender public key recovered from the signature. chainID is the IoTeX chain ID, and the transaction must be
+// signed with its EVM network ID. The hash of the returned action is verified to be the transaction hash.
+func EthTxToAction(tx *types.Transaction, chainID uint32) (*iotextypes.Action, error) {
+	if tx.Type() != types.LegacyTxType {
+		return nil, fmt.Errorf("invalid tx type = %d, only legacy tx is supported", tx.Type())
+	}
+	evmID := EVMNetworkID(chainID)
+	if !tx.Protected() || tx.ChainId().Cmp(big.NewInt(int64(evmID))) != 0 {
+		return nil, fmt.Errorf("invalid tx chain ID, expecting %d", evmID)
+	}
+
+	// signature in [R || S || V] format where V = 27 + recovery id
+	v, r, s := tx.RawSignatureValues()
+	sig := make([]byte, 65)
+	r.FillBytes(sig[:32])
+	s.FillBytes(sig[32:64])
+	sig[64] = byte(v.Uint64() - 2*uint64(evmID) - 8)
+	signer := types.NewEIP155Signer(tx.ChainId())
+	h := signer.Hash(tx)
+	pk, err := crypto.RecoverPubkey(h[:], sig)
+	if err != nil {
+		return nil, err
+	}
+
+	core := &iotextypes.ActionCore{
+		Version:  ProtocolVersion,
+		Nonce:    tx.Nonce(),
+		GasLimit: tx.Gas(),
+		GasPrice: tx.GasPrice().String(),
+		ChainID:  chainID,
+	}
+	if err := setActionFromRLP(core, tx); err != nil {
+		return nil, err
+	}
+	act := &iotextypes.Action{
+		Core:         core,
+		SenderPubKey: pk.Bytes(),
+		Signature:    sig,
+		Encoding:     iotextypes.Encoding_ETHEREUM_RLP,
+	}
+	actHash, err := ActionHash(act, evmID)
+	if err != nil {
+		return nil, err
+	}
+	if actHash != hash.BytesToHash256(tx.Hash().Bytes()) {
+		return nil, fmt.Errorf("action hash %x does not match tx hash %x", actHash, tx.Hash())
+	}
+	return act, nil
+}
+
+// setActionFromRLP sets the action of core from the recipient, value and data of the transaction
+func setActionFromRLP(core *iotextypes.ActionCore, tx *types.Transaction) error {
+	var to string
+	if tx.To() != nil {
+		ok, err := rlpToNativeAction(core, *tx.To(), tx.Data())
+		if err != nil || ok {
+			return err
+		}
+		addr, err := address.FromBytes(tx.To().Bytes())
+		if err != nil {
+			return err
+		}
+		to = addr.String()
+	}
+	if to != "" && len(tx.Data()) == 0 {
+		core.Action = &iotextypes.ActionCore_Transfer{Transfer: &iotextypes.Transfer{
+			Amount:    tx.Value().String(),
+			Recipient: to,
+		}}
+	} else {
+		core.Action = &iotextypes.ActionCore_Execution{Execution: &iotextypes.Execution{
+			Amount:   tx.Value().String(),
+			Contract: to,
+			Data:     tx.Data(),
+		}}
+	}
+	return nil
+}
@@ -0,0 +1,278 @@
+// Copyright (c) 2020 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package iotex
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/iotexproject/iotex-address/address"
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+)
+
+// the ABI of the staking and rewarding system contracts, which native actions are sent to as Ethereum transactions
+const (
+	_stakingInterfaceABI = `[
+	{"inputs":[{"internalType":"string","name":"candName","type":"string"},{"internalType":"uint256","name":"amount","type":"uint256"},{"internalType":"uint32","name":"duration","type":"uint32"},{"internalType":"bool","name":"autoStake","type":"bool"},{"internalType":"uint8[]","name":"data","type":"uint8[]"}],"name":"createStake","outputs":[],"stateMutability":"nonpayable","type":"function"},
+	{"inputs":[{"internalType":"uint64","name":"bucketIndex","type":"uint64"},{"internalType":"uint8[]","name":"data","type":"uint8[]"}],"name":"unstake","outputs":[],"stateMutability":"nonpayable","type":"function"},
+	{"inputs":[{"internalType":"uint64","name":"bucketIndex","type":"uint64"},{"internalType":"uint8[]","name":"data","type":"uint8[]"}],"name":"withdrawStake","outputs":[],"stateMutability":"nonpayable","type":"function"},
+	{"inputs":[{"internalType":"uint64","name":"bucketIndex","type":"uint64"},{"internalType":"uint256","name":"amount","type":"uint256"},{"internalType":"uint8[]","name":"data","type":"uint8[]"}],"name":"depositToStake","outputs":[],"stateMutability":"nonpayable","type":"function"},
+	{"inputs":[{"internalType":"uint64","name":"bucketIndex","type":"uint64"},{"internalType":"uint32","name":"duration","type":"uint32"},{"internalType":"bool","name":"autoStake","type":"bool"},{"internalType":"uint8[]","name":"data","type":"uint8[]"}],"name":"restake","outputs":[],"stateMutability":"nonpayable","type":"function"},
+	{"inputs":[{"internalType":"string","name":"candName","type":"string"},{"internalType":"uint64","name":"bucketIndex","type":"uint64"},{"internalType":"uint8[]","name":"data","type":"uint8[]"}],"name":"changeCandidate","outputs":[],"stateMutability":"nonpayable","type":"function"},
+	{"inputs":[{"internalType":"address","name":"voterAddress","type":"address"},{"internalType":"uint64","name":"bucketIndex","type":"uint64"},{"internalType":"uint8[]","name":"data","type":"uint8[]"}],"name":"transferStake","outputs":[],"stateMutability":"nonpayable","type":"function"},
+	{"inputs":[{"internalType":"string","name":"name","type":"string"},{"internalType":"address","name":"operatorAddress","type":"address"},{"internalType":"address","name":"rewardAddress","type":"address"},{"internalType":"address","name":"ownerAddress","type":"address"},{"internalType":"uint256","name":"amount","type":"uint256"},{"internalType":"uint32","name":"duration","type":"uint32"},{"internalType":"bool","name":"autoStake","type":"bool"},{"internalType":"uint8[]","name":"data","type":"uint8[]"}],"name":"candidateRegister","outputs":[],"stateMutability":"nonpayable","type":"function"},
+	{"inputs":[{"internalType":"string","name":"name","type":"string"},{"internalType":"address","name":"operatorAddress","type":"address"},{"internalType":"address","name":"rewardAddress","type":"address"}],"name":"candidateUpdate","outputs":[],"stateMutability":"nonpayable","type":"function"}
+]`
+	_rewardingInterfaceABI = `[
+	{"inputs":[{"internalType":"uint256","name":"amount","type":"uint256"},{"internalType":"uint8[]","name":"data","type":"uint8[]"}],"name":"claim","outputs":[],"stateMutability":"nonpayable","type":"function"}
+]`
+)
+
+var (
+	_stakingABI   = mustParseABI(_stakingInterfaceABI)
+	_rewardingABI = mustParseABI(_rewardingInterfaceABI)
+
+	_stakingProtocolEthAddr   = common.BytesToAddress(address.StakingProtocolAddrHash[:])
+	_rewardingProtocolEthAddr = common.BytesToAddress(address.RewardingProtocolAddrHash[:])
+)
+
+func mustParseABI(s string) abi.ABI {
+	parsed, err := abi.JSON(strings.NewReader(s))
+	if err != nil {
+		panic(err)
+	}
+	return parsed
+}
+
+// nativeActionToRLP returns the system contract address and the ABI-encoded call of a staking or rewarding
+// action. ok is false if the action is not one of them.
+func nativeActionToRLP(core *iotextypes.ActionCore) (to common.Address, data []byte, ok bool, err error) {
+	to = _stakingProtocolEthAddr
+	switch a := core.GetAction().(type) {
+	case *iotextypes.ActionCore_StakeCreate:
+		amount, e := parseAmount(a.StakeCreate.GetStakedAmount())
+		if e != nil {
+			return to, nil, true, e
+		}
+		data, err = _stakingABI.Pack("createStake", a.StakeCreate.GetCandidateName(), amount,
+			a.StakeCreate.GetStakedDuration(), a.StakeCreate.GetAutoStake(), payloadOf(a.StakeCreate.GetPayload()))
+	case *iotextypes.ActionCore_StakeUnstake:
+		data, err = _stakingABI.Pack("unstake", a.StakeUnstake.GetBucketIndex(), payloadOf(a.StakeUnstake.GetPayload()))
+	case *iotextypes.ActionCore_StakeWithdraw:
+		data, err = _stakingABI.Pack("withdrawStake", a.StakeWithdraw.GetBucketIndex(), payloadOf(a.StakeWithdraw.GetPayload()))
+	case *iotextypes.ActionCore_StakeAddDeposit:
+		amount, e := parseAmount(a.StakeAddDeposit.GetAmount())
+		if e != nil {
+			return to, nil, true, e
+		}
+		data, err = _stakingABI.Pack("depositToStake", a.StakeAddDeposit.GetBucketIndex(), amount,
+			payloadOf(a.StakeAddDeposit.GetPayload()))
+	case *iotextypes.ActionCore_StakeRestake:
+		data, err = _stakingABI.Pack("restake", a.StakeRestake.GetBucketIndex(), a.StakeRestake.GetStakedDuration(),
+			a.StakeRestake.GetAutoStake(), payloadOf(a.StakeRestake.GetPayload()))
+	case *iotextypes.ActionCore_StakeChangeCandidate:
+		data, err = _stakingABI.Pack("changeCandidate", a.StakeChangeCandidate.GetCandidateName(),
+			a.StakeChangeCandidate.GetBucketIndex(), payloadOf(a.StakeChangeCandidate.GetPayload()))
+	case *iotextypes.ActionCore_StakeTransferOwnership:
+		voter, e := ethAddressOf(a.StakeTransferOwnership.GetVoterAddress())
+		if e != nil {
+			return to, nil, true, e
+		}
+		data, err = _stakingABI.Pack("transferStake", voter, a.StakeTransferOwnership.GetBucketIndex(),
+			payloadOf(a.StakeTransferOwnership.GetPayload()))
+	case *iotextypes.ActionCore_CandidateRegister:
+		cr := a.CandidateRegister
+		var addrs [3]common.Address
+		for i, s := range []string{cr.GetCandidate().GetOperatorAddress(), cr.GetCandidate().GetRewardAddress(), cr.GetOwnerAddress()} {
+			if addrs[i], err = ethAddressOf(s); err != nil {
+				return to, nil, true, err
+			}
+		}
+		amount, e := parseAmount(cr.GetStakedAmount())
+		if e != nil {
+			return to, nil, true, e
+		}
+		data, err = _stakingABI.Pack("candidateRegister", cr.GetCandidate().GetName(), addrs[0], addrs[1], addrs[2],
+			amount, cr.GetStakedDuration(), cr.GetAutoStake(), payloadOf(cr.GetPayload()))
+	case *iotextypes.ActionCore_CandidateUpdate:
+		cu := a.CandidateUpdate
+		var addrs [2]common.Address
+		for i, s := range []string{cu.GetOperatorAddress(), cu.GetRewardAddress()} {
+			if addrs[i], err = ethAddressOf(s); err != nil {
+				return to, nil, true, err
+			}
+		}
+		data, err = _stakingABI.Pack("candidateUpdate", cu.GetName(), addrs[0], addrs[1])
+	case *iotextypes.ActionCore_ClaimFromRewardingFund:
+		to = _rewardingProtocolEthAddr
+		amount, e := parseAmount(a.ClaimFromRewardingFund.GetAmount())
+		if e != nil {
+			return to, nil, true, e
+		}
+		data, err = _rewardingABI.Pack("claim", amount, payloadOf(a.ClaimFromRewardingFund.GetData()))
+	default:
+		return to, nil, false, nil
+	}
+	return to, data, true, err
+}
+
+// rlpToNativeAction decodes the ABI-encoded call to a staking or rewarding system contract into the action of
+// core. ok is false if to is not a system contract.
+func rlpToNativeAction(core *iotextypes.ActionCore, to common.Address, data []byte) (ok bool, err error) {
+	var contractABI *abi.ABI
+	switch to {
+	case _stakingProtocolEthAddr:
+		contractABI = &_stakingABI
+	case _rewardingProtocolEthAddr:
+		contractABI = &_rewardingABI
+	default:
+		return false, nil
+	}
+	if len(data) < 4 {
+		return true, fmt.Errorf("invalid system contract call data length = %d", len(data))
+	}
+	method, err := contractABI.MethodById(data[:4])
+	if err != nil {
+		return true, err
+	}
+	args, err := method.Inputs.Unpack(data[4:])
+	if err != nil {
+		return true, err
+	}
+
+	switch method.Name {
+	case "createStake":
+		core.Action = &iotextypes.ActionCore_StakeCreate{StakeCreate: &iotextypes.StakeCreate{
+			CandidateName:  args[0].(string),
+			StakedAmount:   args[1].(*big.Int).String(),
+			StakedDuration: args[2].(uint32),
+			AutoStake:      args[3].(bool),
+			Payload:        payloadOf(args[4].([]uint8)),
+		}}
+	case "unstake":
+		core.Action = &iotextypes.ActionCore_StakeUnstake{StakeUnstake: &iotextypes.StakeReclaim{
+			BucketIndex: args[0].(uint64),
+			Payload:     payloadOf(args[1].([]uint8)),
+		}}
+	case "withdrawStake":
+		core.Action = &iotextypes.ActionCore_StakeWithdraw{StakeWithdraw: &iotextypes.StakeReclaim{
+			BucketIndex: args[0].(uint64),
+			Payload:     payloadOf(args[1].([]uint8)),
+		}}
+	case "depositToStake":
+		core.Action = &iotextypes.ActionCore_StakeAddDeposit{StakeAddDeposit: &iotextypes.StakeAddDeposit{
+			BucketIndex: args[0].(uint64),
+			Amount:      args[1].(*big.Int).String(),
+			Payload:     payloadOf(args[2].([]uint8)),
+		}}
+	case "restake":
+		core.Action = &iotextypes.ActionCore_StakeRestake{StakeRestake: &iotextypes.StakeRestake{
+			BucketIndex:    args[0].(uint64),
+			StakedDuration: args[1].(uint32),
+			AutoStake:      args[2].(bool),
+			Payload:        payloadOf(args[3].([]uint8)),
+		}}
+	case "changeCandidate":
+		core.Action = &iotextypes.ActionCore_StakeChangeCandidate{StakeChangeCandidate: &iotextypes.StakeChangeCandidate{
+			CandidateName: args[0].(string),
+			BucketIndex:   args[1].(uint64),
+			Payload:       payloadOf(args[2].([]uint8)),
+		}}
+	case "transferStake":
+		voter, err := ioAddressOf(args[0].(common.Address))
+		if err != nil {
+			return true, err
+		}
+		core.Action = &iotextypes.ActionCore_StakeTransferOwnership{StakeTransferOwnership: &iotextypes.StakeTransferOwnership{
+			VoterAddress: voter,
+			BucketIndex:  args[1].(uint64),
+			Payload:      payloadOf(args[2].([]uint8)),
+		}}
+	case "candidateRegister":
+		var addrs [3]string
+		for i := range addrs {
+			if addrs[i], err = ioAddressOf(args[i+1].(common.Address)); err != nil {
+				return true, err
+			}
+		}
+		core.Action = &iotextypes.ActionCore_CandidateRegister{CandidateRegister: &iotextypes.CandidateRegister{
+			Candidate: &iotextypes.CandidateBasicInfo{
+				Name:            args[0].(string),
+				OperatorAddress: addrs[0],
+				RewardAddress:   addrs[1],
+			},
+			OwnerAddress:   addrs[2],
+			StakedAmount:   args[4].(*big.Int).String(),
+			StakedDuration: args[5].(uint32),
+			AutoStake:      args[6].(bool),
+			Payload:        payloadOf(args[7].([]uint8)),
+		}}
+	case "candidateUpdate":
+		var addrs [2]string
+		for i := range addrs {
+			if addrs[i], err = ioAddressOf(args[i+1].(common.Address)); err != nil {
+				return true, err
+			}
+		}
+		core.Action = &iotextypes.ActionCore_CandidateUpdate{CandidateUpdate: &iotextypes.CandidateBasicInfo{
+			Name:            args[0].(string),
+			OperatorAddress: addrs[0],
+			RewardAddress:   addrs[1],
+		}}
+	case "claim":
+		core.Action = &iotextypes.ActionCore_ClaimFromRewardingFund{ClaimFromRewardingFund: &iotextypes.ClaimFromRewardingFund{
+			Amount: args[0].(*big.Int).String(),
+			Data:   payloadOf(args[1].([]uint8)),
+		}}
+	default:
+		return true, fmt.Errorf("invalid system contract method %s", method.Name)
+	}
+	return true, nil
+}
+
+func parseAmount(s string) (*big.Int, error) {
+	amount, ok := new(big.Int).SetString(s, 10)
+	if !ok {
+		return nil, fmt.Errorf("invalid amount %s", s)
+	}
+	return amount, nil
+}
+
+// payloadOf returns nil for an empty payload, so a decoded action equals the one it was encoded from
+func payloadOf(pl []byte) []byte {
+	if len(pl) == 0 {
+		return nil
+	}
+	return pl
+}
+
+// ethAddressOf converts an io address into Ethereum address, an empty address is the zero address
+func ethAddressOf(s string) (common.Address, error) {
+	if s == "" {
+		return common.Address{}, nil
+	}
+	addr, err := address.FromString(s)
+	if err != nil {
+		return common.Address{}, err
+	}
+	return common.BytesToAddress(addr.Bytes()), nil
+}
+
+// ioAddressOf converts an Ethereum address into io address, the zero address is empty
+func ioAddressOf(a common.Address) (string, error) {
+	if a == (common.Address{}) {
+		return "", nil
+	}
+	addr, err := address.FromBytes(a.Bytes())
+	if err != nil {
+		return "", err
+	}
+	return addr.String(), nil
+}
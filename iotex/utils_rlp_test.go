@@ -0,0 +1,158 @@
+// Copyright (c) 2020 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package iotex
+
+import (
+	"context"
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	ethCrypto "github.com/ethereum/go-ethereum/crypto"
+	"github.com/golang/mock/gomock"
+	"github.com/golang/protobuf/proto"
+	"github.com/iotexproject/iotex-address/address"
+	"github.com/iotexproject/iotex-proto/golang/iotexapi"
+	"github.com/iotexproject/iotex-proto/golang/iotexapi/mock_iotexapi"
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-antenna-go/v2/account"
+)
+
+func TestEVMNetworkID(t *testing.T) {
+	require := require.New(t)
+	require.EqualValues(MainnetEVMNetworkID, EVMNetworkID(1))
+	require.EqualValues(TestnetEVMNetworkID, EVMNetworkID(2))
+	require.EqualValues(31337, EVMNetworkID(31337))
+}
+
+func TestTransferWithEthereumRLP(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	acc, err := account.HexStringToAccount(_accountPrivateKey)
+	require.NoError(err)
+	to, err := address.FromString(_to)
+	require.NoError(err)
+
+	// the same transaction signed by an Ethereum wallet
+	sk, err := ethCrypto.HexToECDSA(_accountPrivateKey)
+	require.NoError(err)
+	ethTx, err := types.SignTx(
+		types.NewTransaction(3, toEthAddress(to), big.NewInt(100), 21000, big.NewInt(1000000000000), nil),
+		types.NewEIP155Signer(big.NewInt(TestnetEVMNetworkID)), sk)
+	require.NoError(err)
+
+	api := mock_iotexapi.NewMockAPIServiceClient(ctrl)
+	api.EXPECT().SendAction(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, in *iotexapi.SendActionRequest, _ ...interface{}) (*iotexapi.SendActionResponse, error) {
+			act := in.GetAction()
+			require.Equal(iotextypes.Encoding_ETHEREUM_RLP, act.GetEncoding())
+			require.EqualValues(2, act.GetCore().GetChainID())
+			expected, err := EthTxToAction(ethTx, 2)
+			require.NoError(err)
+			require.Equal(expected.GetSignature(), act.GetSignature())
+			require.Equal(expected.GetSenderPubKey(), act.GetSenderPubKey())
+			h, err := ActionHash(act, TestnetEVMNetworkID)
+			require.NoError(err)
+			return &iotexapi.SendActionResponse{ActionHash: hex.EncodeToString(h[:])}, nil
+		})
+
+	c := NewAuthedClient(api, 2, acc, WithEthereumRLP())
+	h, err := c.Transfer(to, big.NewInt(100)).
+		SetNonce(3).SetGasLimit(21000).SetGasPrice(big.NewInt(1000000000000)).Call(context.Background())
+	require.NoError(err)
+	require.Equal(ethTx.Hash().Bytes(), h[:])
+
+	// offline signing keeps the encoding
+	unsigned, err := BuildAction(context.Background(), c.Transfer(to, big.NewInt(100)).
+		SetNonce(3).SetGasLimit(21000).SetGasPrice(big.NewInt(1000000000000)))
+	require.NoError(err)
+	data, err := unsigned.Serialize()
+	require.NoError(err)
+	unsigned, err = DeserializeUnsignedAction(data)
+	require.NoError(err)
+	require.Equal(iotextypes.Encoding_ETHEREUM_RLP, unsigned.Encoding)
+	sealed, err := unsigned.Sign(acc)
+	require.NoError(err)
+	actHash, err := ActionHash(sealed, TestnetEVMNetworkID)
+	require.NoError(err)
+	require.Equal(ethTx.Hash().Bytes(), actHash[:])
+
+	// a watch-only account cannot sign
+	_, err = unsigned.Sign(account.AddressToAccount(acc.Address()))
+	require.Error(err)
+}
+
+func TestNativeActionRLP(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	acc, err := account.HexStringToAccount(_accountPrivateKey)
+	require.NoError(err)
+	sk, err := ethCrypto.HexToECDSA(_accountPrivateKey)
+	require.NoError(err)
+	signer := types.NewEIP155Signer(big.NewInt(TestnetEVMNetworkID))
+	voter, err := address.FromString(_to)
+	require.NoError(err)
+
+	var sent *iotextypes.Action
+	api := mock_iotexapi.NewMockAPIServiceClient(ctrl)
+	api.EXPECT().SendAction(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, in *iotexapi.SendActionRequest, _ ...interface{}) (*iotexapi.SendActionResponse, error) {
+			sent = in.GetAction()
+			h, err := ActionHash(sent, TestnetEVMNetworkID)
+			require.NoError(err)
+			return &iotexapi.SendActionResponse{ActionHash: hex.EncodeToString(h[:])}, nil
+		}).AnyTimes()
+	c := NewAuthedClient(api, 2, acc, WithEthereumRLP())
+	amount := big.NewInt(0).Mul(big.NewInt(100), big.NewInt(1e18))
+
+	for _, test := range []struct {
+		caller   Caller
+		contract string
+	}{
+		{c.Staking().Create("robotbp00000", amount, 91, true).SetPayload([]byte("payload")), address.StakingProtocolAddr},
+		{c.Staking().Unstake(10), address.StakingProtocolAddr},
+		{c.Staking().Withdraw(10), address.StakingProtocolAddr},
+		{c.Staking().AddDeposit(10, amount), address.StakingProtocolAddr},
+		{c.Staking().ChangeCandidate("robotbp00001", 10), address.StakingProtocolAddr},
+		{c.Staking().StakingTransfer(voter, 10), address.StakingProtocolAddr},
+		{c.Staking().Restake(10, 7, false), address.StakingProtocolAddr},
+		{c.Candidate().Register("robotbp00002", acc.Address(), voter, voter, amount, 7, true, nil), address.StakingProtocolAddr},
+		{c.Candidate().Update("robotbp00002", voter, acc.Address()), address.StakingProtocolAddr},
+		{c.ClaimReward(amount), address.RewardingProtocol},
+	} {
+		switch caller := test.caller.(type) {
+		case SendActionCaller:
+			caller.SetNonce(1).SetGasLimit(20000).SetGasPrice(big.NewInt(1000000000000))
+		case ClaimRewardCaller:
+			caller.SetNonce(1).SetGasLimit(20000).SetGasPrice(big.NewInt(1000000000000))
+		}
+		h, err := test.caller.Call(context.Background())
+		require.NoError(err)
+
+		// the same transaction signed by an Ethereum wallet
+		tx, err := actionToRLP(sent.GetCore())
+		require.NoError(err)
+		contract, err := address.FromString(test.contract)
+		require.NoError(err)
+		require.Equal(toEthAddress(contract), *tx.To())
+		ethTx, err := types.SignTx(tx, signer, sk)
+		require.NoError(err)
+		require.Equal(ethTx.Hash().Bytes(), h[:])
+
+		// and decoded back
+		decoded, err := EthTxToAction(ethTx, 2)
+		require.NoError(err)
+		require.True(proto.Equal(sent, decoded), "%v != %v", sent, decoded)
+	}
+}
@@ -0,0 +1,80 @@
+// Copyright (c) 2020 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package jwt
+
+import (
+	"crypto/ecdsa"
+	"crypto/sha256"
+
+	ethCrypto "github.com/ethereum/go-ethereum/crypto"
+	"github.com/golang-jwt/jwt"
+	"github.com/iotexproject/go-pkgs/crypto"
+
+	"github.com/iotexproject/iotex-antenna-go/v2/account"
+)
+
+// SigningMethodES256K is ECDSA on secp256k1 with SHA-256 of RFC 8812, the signature is [R || S] with low S. The
+// signatures with high S of the other signers are accepted as well.
+var SigningMethodES256K jwt.SigningMethod = &signingMethodES256K{}
+
+type signingMethodES256K struct{}
+
+func init() {
+	jwt.RegisterSigningMethod(SigningMethodES256K.Alg(), func() jwt.SigningMethod {
+		return SigningMethodES256K
+	})
+}
+
+func (m *signingMethodES256K) Alg() string {
+	return "ES256K"
+}
+
+// Sign signs the string with the key, which is an account.Signer or a crypto.PrivateKey
+func (m *signingMethodES256K) Sign(signingString string, key interface{}) (string, error) {
+	var (
+		s   account.Signer
+		err error
+	)
+	switch k := key.(type) {
+	case account.Signer:
+		s = k
+	case crypto.PrivateKey:
+		if s, err = account.PrivateKeySigner(k); err != nil {
+			return "", err
+		}
+	default:
+		return "", jwt.ErrInvalidKeyType
+	}
+	h := sha256.Sum256([]byte(signingString))
+	sig, err := account.SignHash(s, h[:])
+	if err != nil {
+		return "", err
+	}
+	return jwt.EncodeSegment(sig[:64]), nil
+}
+
+// Verify verifies the signature of the string with the key, which is a crypto.PublicKey or an *ecdsa.PublicKey
+func (m *signingMethodES256K) Verify(signingString, signature string, key interface{}) error {
+	var pub []byte
+	switch k := key.(type) {
+	case crypto.PublicKey:
+		pub = k.Bytes()
+	case *ecdsa.PublicKey:
+		pub = ethCrypto.FromECDSAPub(k)
+	default:
+		return jwt.ErrInvalidKeyType
+	}
+	sig, err := jwt.DecodeSegment(signature)
+	if err != nil {
+		return err
+	}
+	h := sha256.Sum256([]byte(signingString))
+	if len(sig) != 64 || !ethCrypto.VerifySignature(pub, h[:], account.NormalizeLowS(sig)) {
+		return jwt.ErrECDSAVerification
+	}
+	return nil
+}
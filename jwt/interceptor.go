@@ -0,0 +1,81 @@
+// Copyright (c) 2020 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package jwt
+
+import (
+	"context"
+	"errors"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// MethodScopes maps the full gRPC method name, such as "/iotexapi.APIService/SendAction", to the scope it requires.
+// A method not in the map requires a valid token but no scope.
+type MethodScopes map[string]string
+
+type authServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *authServerStream) Context() context.Context {
+	return s.ctx
+}
+
+// UnaryServerInterceptor returns the gRPC interceptor which verifies the bearer token in the "authorization"
+// metadata, and requires the scope of the method. The verified JWT is put into the context of the handler. The token
+// must be issued by one of the issuers, as in Middleware.
+func UnaryServerInterceptor(scopes MethodScopes, issuers []string, opts ...VerifyOption) (grpc.UnaryServerInterceptor, error) {
+	opts, err := withTrustedIssuers(issuers, opts)
+	if err != nil {
+		return nil, err
+	}
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		ctx, err := authenticateContext(ctx, scopes[info.FullMethod], opts...)
+		if err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}, nil
+}
+
+// StreamServerInterceptor returns the gRPC stream interceptor which verifies the bearer token in the "authorization"
+// metadata, and requires the scope of the method. The verified JWT is put into the context of the stream. The token
+// must be issued by one of the issuers, as in Middleware.
+func StreamServerInterceptor(scopes MethodScopes, issuers []string, opts ...VerifyOption) (grpc.StreamServerInterceptor, error) {
+	opts, err := withTrustedIssuers(issuers, opts)
+	if err != nil {
+		return nil, err
+	}
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, err := authenticateContext(ss.Context(), scopes[info.FullMethod], opts...)
+		if err != nil {
+			return err
+		}
+		return handler(srv, &authServerStream{ServerStream: ss, ctx: ctx})
+	}, nil
+}
+
+func authenticateContext(ctx context.Context, scope string, opts ...VerifyOption) (context.Context, error) {
+	var authorization string
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if v := md.Get("authorization"); len(v) > 0 {
+			authorization = v[0]
+		}
+	}
+	token, err := authenticate(authorization, scope, opts...)
+	switch {
+	case errors.Is(err, ErrInsufficientScope):
+		return nil, status.Errorf(codes.PermissionDenied, "%s: %s is required", err, scope)
+	case err != nil:
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+	return NewContext(ctx, token), nil
+}
@@ -7,11 +7,20 @@
 package jwt
 
 import (
+	"crypto/sha256"
 	"encoding/hex"
 	"errors"
+	"fmt"
+	"strings"
+	"time"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/golang-jwt/jwt"
 	"github.com/iotexproject/go-pkgs/crypto"
+	"github.com/iotexproject/iotex-address/address"
+
+	"github.com/iotexproject/iotex-antenna-go/v2/account"
+	"github.com/iotexproject/iotex-antenna-go/v2/did"
 )
 
 // const
@@ -22,16 +31,55 @@ const (
 	DELETE = "Delete"
 )
 
+// IssuerFormat is the format of the issuer of a JWT
+type IssuerFormat int
+
+// issuer formats
+const (
+	// IssuerPublicKey is the hex public key with 0x prefix, the default
+	IssuerPublicKey IssuerFormat = iota
+	// IssuerAddress is the io1 address
+	IssuerAddress
+	// IssuerDID is the did:io DID
+	IssuerDID
+)
+
 type (
 	// JWT is a JWT object
 	JWT struct {
 		IssuedAt   int64
 		ExpiresAt  int64
+		NotBefore  int64
 		Issuer     string
 		Subject    string
+		Audience   string
 		Scope      string
 		SignMethod string
 		SigHex     string
+		// Address is the address of the issuer
+		Address address.Address
+		// Scopes is CREATE, READ, UPDATE and DELETE in the scope
+		Scopes []string
+	}
+
+	// SignOption is an option to sign a JWT
+	SignOption func(*signConfig)
+
+	// VerifyOption is an option to verify a JWT
+	VerifyOption func(*verifyConfig)
+
+	signConfig struct {
+		audience     string
+		notBefore    int64
+		issuerFormat IssuerFormat
+	}
+
+	verifyConfig struct {
+		audience string
+		leeway   time.Duration
+		issuers  map[string]bool
+		// err is the error of the options
+		err error
 	}
 
 	claimWithScope struct {
@@ -40,34 +88,128 @@ type (
 	}
 )
 
-// SignJWT creates a JWT
-func SignJWT(issue, expire int64, subject, scope string, key crypto.PrivateKey) (string, error) {
+// WithAudience sets the audience of the JWT
+func WithAudience(aud string) SignOption {
+	return func(c *signConfig) {
+		c.audience = aud
+	}
+}
+
+// WithNotBefore sets the time before which the JWT is not valid
+func WithNotBefore(nbf int64) SignOption {
+	return func(c *signConfig) {
+		c.notBefore = nbf
+	}
+}
+
+// WithIssuerFormat sets the format of the issuer, default is IssuerPublicKey
+func WithIssuerFormat(f IssuerFormat) SignOption {
+	return func(c *signConfig) {
+		c.issuerFormat = f
+	}
+}
+
+// ExpectAudience makes VerifyJWT reject the JWT not for the audience
+func ExpectAudience(aud string) VerifyOption {
+	return func(c *verifyConfig) {
+		c.audience = aud
+	}
+}
+
+// WithLeeway sets the clock skew allowed when checking the times of the JWT
+func WithLeeway(d time.Duration) VerifyOption {
+	return func(c *verifyConfig) {
+		c.leeway = d
+	}
+}
+
+// WithIssuers makes VerifyJWT accept the JWT issued by the issuers only. An issuer can be a hex public key, an io1
+// address, a 0x address or a did:io DID. VerifyJWT fails on an invalid issuer, instead of skipping it.
+func WithIssuers(issuers ...string) VerifyOption {
+	return func(c *verifyConfig) {
+		if c.issuers == nil {
+			c.issuers = make(map[string]bool)
+		}
+		for _, iss := range issuers {
+			addr, err := issuerAddress(iss)
+			if err != nil {
+				if c.err == nil {
+					c.err = fmt.Errorf("invalid issuer %s: %v", iss, err)
+				}
+				continue
+			}
+			c.issuers[addr.String()] = true
+		}
+	}
+}
+
+// SignJWT creates a JWT signed in ES256K
+func SignJWT(issue, expire int64, subject, scope string, key crypto.PrivateKey, opts ...SignOption) (string, error) {
+	s, err := account.PrivateKeySigner(key)
+	if err != nil {
+		return "", err
+	}
+	return SignJWTWithSigner(issue, expire, subject, scope, s, opts...)
+}
+
+// SignJWTWithSigner creates a JWT signed in ES256K by the signer, such as a remote signer
+func SignJWTWithSigner(issue, expire int64, subject, scope string, s account.Signer, opts ...SignOption) (string, error) {
+	cfg := signConfig{}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	var issuer string
+	switch cfg.issuerFormat {
+	case IssuerPublicKey:
+		issuer = "0x" + s.PublicKey().HexString()
+	case IssuerAddress:
+		issuer = s.Address().String()
+	case IssuerDID:
+		issuer = did.DIDPrefix + "0x" + hex.EncodeToString(s.Address().Bytes())
+	default:
+		return "", fmt.Errorf("invalid issuer format %d", cfg.issuerFormat)
+	}
 	c := &claimWithScope{
 		StandardClaims: jwt.StandardClaims{
+			Audience:  cfg.audience,
 			ExpiresAt: expire,
 			IssuedAt:  issue,
-			Issuer:    "0x" + key.PublicKey().HexString(),
+			Issuer:    issuer,
+			NotBefore: cfg.notBefore,
 			Subject:   subject,
 		},
 		Scope: scope,
 	}
-	token := jwt.NewWithClaims(jwt.SigningMethodES256, c)
-	return token.SignedString(key.EcdsaPrivateKey())
+	return jwt.NewWithClaims(SigningMethodES256K, c).SignedString(s)
 }
 
-// VerifyJWT verifies the JWT
-func VerifyJWT(jwtString string) (*JWT, error) {
+// VerifyJWT verifies the JWT signed in ES256K, or in ES256 by the former versions, and validates its claims
+func VerifyJWT(jwtString string, opts ...VerifyOption) (*JWT, error) {
+	cfg := verifyConfig{}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	if cfg.err != nil {
+		return nil, cfg.err
+	}
 	claim := &claimWithScope{}
-	token, err := jwt.ParseWithClaims(jwtString, claim, func(token *jwt.Token) (interface{}, error) {
-		keyHex := claim.Issuer
-		if keyHex[:2] == "0x" || keyHex[:2] == "0X" {
-			keyHex = keyHex[2:]
-		}
-		key, err := crypto.HexStringToPublicKey(keyHex)
+	parser := &jwt.Parser{
+		ValidMethods:         []string{SigningMethodES256K.Alg(), jwt.SigningMethodES256.Alg()},
+		SkipClaimsValidation: true,
+	}
+	var addr address.Address
+	token, err := parser.ParseWithClaims(jwtString, claim, func(token *jwt.Token) (interface{}, error) {
+		key, err := issuerPublicKey(claim.Issuer, token.Raw)
 		if err != nil {
 			return nil, err
 		}
-		return key.EcdsaPublicKey(), nil
+		if addr, err = address.FromBytes(key.Hash()); err != nil {
+			return nil, err
+		}
+		if token.Method == jwt.SigningMethodES256 {
+			return key.EcdsaPublicKey(), nil
+		}
+		return key, nil
 	})
 	if err != nil {
 		return nil, err
@@ -77,6 +219,9 @@ func VerifyJWT(jwtString string) (*JWT, error) {
 		// should not happen with a success parsing, check anyway
 		return nil, errors.New("invalid token")
 	}
+	if err := cfg.validate(claim, addr); err != nil {
+		return nil, err
+	}
 
 	// decode signature
 	sig, err := jwt.DecodeSegment(token.Signature)
@@ -87,10 +232,109 @@ func VerifyJWT(jwtString string) (*JWT, error) {
 	return &JWT{
 		IssuedAt:   claim.IssuedAt,
 		ExpiresAt:  claim.ExpiresAt,
+		NotBefore:  claim.NotBefore,
 		Issuer:     claim.Issuer,
 		Subject:    claim.Subject,
+		Audience:   claim.Audience,
 		Scope:      claim.Scope,
 		SignMethod: token.Header["alg"].(string),
 		SigHex:     hex.EncodeToString(sig),
+		Address:    addr,
+		Scopes:     ParseScopes(claim.Scope),
 	}, nil
 }
+
+// HasScope returns whether the JWT has the scope, one of CREATE, READ, UPDATE and DELETE
+func (j *JWT) HasScope(scope string) bool {
+	for _, s := range j.Scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseScopes returns CREATE, READ, UPDATE and DELETE in the scope, separated by spaces or commas and case-insensitive.
+// The other scopes are skipped.
+func ParseScopes(scope string) []string {
+	var scopes []string
+	for _, s := range strings.FieldsFunc(scope, func(r rune) bool { return r == ' ' || r == ',' }) {
+		for _, known := range []string{CREATE, READ, UPDATE, DELETE} {
+			if strings.EqualFold(s, known) {
+				scopes = append(scopes, known)
+			}
+		}
+	}
+	return scopes
+}
+
+func (c *verifyConfig) validate(claim *claimWithScope, issuer address.Address) error {
+	// the times are in seconds, as the claims
+	now, leeway := time.Now().Unix(), int64(c.leeway/time.Second)
+	if claim.ExpiresAt != 0 && now > claim.ExpiresAt+leeway {
+		return fmt.Errorf("token is expired by %v", time.Duration(now-claim.ExpiresAt)*time.Second)
+	}
+	if claim.IssuedAt != 0 && now+leeway < claim.IssuedAt {
+		return errors.New("Token used before issued")
+	}
+	if claim.NotBefore != 0 && now+leeway < claim.NotBefore {
+		return errors.New("token is not valid yet")
+	}
+	if c.audience != "" && claim.Audience != c.audience {
+		return fmt.Errorf("token audience %s is not %s", claim.Audience, c.audience)
+	}
+	if c.issuers != nil && !c.issuers[issuer.String()] {
+		return fmt.Errorf("token issuer %s is not allowed", claim.Issuer)
+	}
+	return nil
+}
+
+// issuerPublicKey returns the public key of the issuer. If the issuer is an address or a DID, the public key is
+// recovered from the signature of the token.
+func issuerPublicKey(issuer, raw string) (crypto.PublicKey, error) {
+	if len(issuer) == 132 && (issuer[:2] == "0x" || issuer[:2] == "0X") {
+		return crypto.HexStringToPublicKey(issuer[2:])
+	}
+	addr, err := issuerAddress(issuer)
+	if err != nil {
+		return nil, err
+	}
+	i := strings.LastIndexByte(raw, '.')
+	if i < 0 {
+		return nil, jwt.ErrECDSAVerification
+	}
+	sig, err := jwt.DecodeSegment(raw[i+1:])
+	if err != nil {
+		return nil, err
+	}
+	if len(sig) != 64 {
+		return nil, jwt.ErrECDSAVerification
+	}
+	sig = account.NormalizeLowS(sig)
+	h := sha256.Sum256([]byte(raw[:i]))
+	for v := byte(0); v < 2; v++ {
+		pk, err := crypto.RecoverPubkey(h[:], append(sig[:64:64], v))
+		if err == nil && pk.Address().String() == addr.String() {
+			return pk, nil
+		}
+	}
+	return nil, jwt.ErrECDSAVerification
+}
+
+// issuerAddress returns the address of the issuer, which is a hex public key, an io1 address, a 0x address or a
+// did:io DID
+func issuerAddress(issuer string) (address.Address, error) {
+	issuer = strings.TrimPrefix(issuer, did.DIDPrefix)
+	switch {
+	case len(issuer) == 132 && (issuer[:2] == "0x" || issuer[:2] == "0X"):
+		pk, err := crypto.HexStringToPublicKey(issuer[2:])
+		if err != nil {
+			return nil, err
+		}
+		return address.FromBytes(pk.Hash())
+	case common.IsHexAddress(issuer):
+		return address.FromBytes(common.HexToAddress(issuer).Bytes())
+	default:
+		return address.FromString(issuer)
+	}
+}
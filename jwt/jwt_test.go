@@ -2,13 +2,17 @@ package jwt
 
 import (
 	"encoding/hex"
+	"math/big"
 	"strings"
 	"testing"
 	"time"
 
+	ethCrypto "github.com/ethereum/go-ethereum/crypto"
 	"github.com/golang-jwt/jwt"
 	"github.com/iotexproject/go-pkgs/crypto"
 	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-antenna-go/v2/account"
 )
 
 func TestDecodeJWT(t *testing.T) {
@@ -108,7 +112,7 @@ func TestSignVerifyJWT(t *testing.T) {
 		r.Equal(issuer, token.Issuer)
 		r.Equal(v.url, token.Subject)
 		r.Equal(v.scope, token.Scope)
-		r.Equal("ES256", token.SignMethod)
+		r.Equal("ES256K", token.SignMethod)
 
 		// signing the token with a diff key fails the verification
 		claim := &claimWithScope{
@@ -133,3 +137,126 @@ func TestSignVerifyJWT(t *testing.T) {
 		r.Equal(jwt.ErrECDSAVerification.Error(), err.Error())
 	}
 }
+
+func TestSignJWTWithSigner(t *testing.T) {
+	r := require.New(t)
+
+	acc, err := account.NewAccount()
+	r.NoError(err)
+	s, err := account.AsSigner(acc)
+	r.NoError(err)
+	now := time.Now().Unix()
+	jwtStr, err := SignJWTWithSigner(now, now+10, "http://example.come/1234", READ, s)
+	r.NoError(err)
+	token, err := VerifyJWT(jwtStr)
+	r.NoError(err)
+	r.Equal("0x"+acc.PublicKey().HexString(), token.Issuer)
+	r.Equal(READ, token.Scope)
+	r.Equal("ES256K", token.SignMethod)
+}
+
+func TestES256K(t *testing.T) {
+	r := require.New(t)
+
+	sk, err := crypto.GenerateKey()
+	r.NoError(err)
+	now := time.Now().Unix()
+	jwtStr, err := SignJWT(now, now+10, "http://example.come/1234", READ, sk)
+	r.NoError(err)
+	parts := strings.Split(jwtStr, ".")
+	r.Len(parts, 3)
+	header, err := jwt.DecodeSegment(parts[0])
+	r.NoError(err)
+	r.JSONEq(`{"alg":"ES256K","typ":"JWT"}`, string(header))
+	sig, err := jwt.DecodeSegment(parts[2])
+	r.NoError(err)
+	r.Len(sig, 64)
+	// verified by the standard ECDSA on secp256k1, as the libraries of other languages
+	r.NoError(SigningMethodES256K.Verify(parts[0]+"."+parts[1], parts[2], sk.PublicKey().EcdsaPublicKey()))
+	r.Equal(jwt.ErrECDSAVerification, SigningMethodES256K.Verify(parts[0]+"."+parts[0], parts[2], sk.PublicKey()))
+	_, err = SigningMethodES256K.Sign(parts[0], "not a key")
+	r.Equal(jwt.ErrInvalidKeyType, err)
+
+	// the issuer can be an address or a DID
+	addr := sk.PublicKey().Address()
+	for _, f := range []IssuerFormat{IssuerPublicKey, IssuerAddress, IssuerDID} {
+		jwtStr, err := SignJWT(now, now+10, "sub", READ+" "+DELETE, sk, WithIssuerFormat(f))
+		r.NoError(err)
+		token, err := VerifyJWT(jwtStr)
+		r.NoError(err)
+		r.Equal(addr.String(), token.Address.String())
+		r.Equal([]string{READ, DELETE}, token.Scopes)
+		r.True(token.HasScope(DELETE))
+		r.False(token.HasScope(CREATE))
+	}
+	jwtStr, err = SignJWT(now, now+10, "sub", READ, sk, WithIssuerFormat(IssuerDID))
+	r.NoError(err)
+	token, err := VerifyJWT(jwtStr)
+	r.NoError(err)
+	r.Equal("did:io:0x"+hex.EncodeToString(addr.Bytes()), token.Issuer)
+
+	// the signature with high S of the other signers is accepted, for the issuer of a public key or an address
+	for _, f := range []IssuerFormat{IssuerPublicKey, IssuerAddress} {
+		jwtStr, err := SignJWT(now, now+10, "sub", READ, sk, WithIssuerFormat(f))
+		r.NoError(err)
+		i := strings.LastIndexByte(jwtStr, '.')
+		sig, err := jwt.DecodeSegment(jwtStr[i+1:])
+		r.NoError(err)
+		highS := append([]byte{}, sig[:32]...)
+		highS = append(highS, new(big.Int).Sub(ethCrypto.S256().Params().N, new(big.Int).SetBytes(sig[32:])).FillBytes(make([]byte, 32))...)
+		token, err := VerifyJWT(jwtStr[:i+1] + jwt.EncodeSegment(highS))
+		r.NoError(err)
+		r.Equal(addr.String(), token.Address.String())
+	}
+
+	// a token signed by another key is rejected
+	other, err := crypto.GenerateKey()
+	r.NoError(err)
+	claim := &claimWithScope{StandardClaims: jwt.StandardClaims{IssuedAt: now, Issuer: addr.String()}}
+	forged, err := jwt.NewWithClaims(SigningMethodES256K, claim).SignedString(other)
+	r.NoError(err)
+	_, err = VerifyJWT(forged)
+	r.Error(err)
+}
+
+func TestVerifyJWTClaims(t *testing.T) {
+	r := require.New(t)
+
+	sk, err := crypto.GenerateKey()
+	r.NoError(err)
+	other, err := crypto.GenerateKey()
+	r.NoError(err)
+	now := time.Now().Unix()
+
+	jwtStr, err := SignJWT(now, now+10, "sub", "read,Update,https://example.com", sk, WithAudience("example.com"),
+		WithNotBefore(now-1))
+	r.NoError(err)
+	token, err := VerifyJWT(jwtStr, ExpectAudience("example.com"), WithIssuers(sk.PublicKey().Address().String()))
+	r.NoError(err)
+	r.Equal("example.com", token.Audience)
+	r.Equal(now-1, token.NotBefore)
+	r.Equal([]string{READ, UPDATE}, token.Scopes)
+	_, err = VerifyJWT(jwtStr, ExpectAudience("evil.com"))
+	r.Contains(err.Error(), "audience")
+	_, err = VerifyJWT(jwtStr, WithIssuers(other.PublicKey().Address().String(), "did:io:0x0000000000000000000000000000000000000000"))
+	r.Contains(err.Error(), "not allowed")
+	_, err = VerifyJWT(jwtStr, WithIssuers("0x"+sk.PublicKey().HexString()))
+	r.NoError(err)
+	// an invalid issuer fails the verification, instead of being skipped
+	_, err = VerifyJWT(jwtStr, WithIssuers(sk.PublicKey().Address().String(), "io1typo"))
+	r.Contains(err.Error(), "invalid issuer io1typo")
+
+	// not before and clock skew
+	jwtStr, err = SignJWT(now, now+60, "sub", READ, sk, WithNotBefore(now+5))
+	r.NoError(err)
+	_, err = VerifyJWT(jwtStr)
+	r.Equal("token is not valid yet", err.Error())
+	_, err = VerifyJWT(jwtStr, WithLeeway(10*time.Second))
+	r.NoError(err)
+	jwtStr, err = SignJWT(now-20, now-5, "sub", READ, sk)
+	r.NoError(err)
+	_, err = VerifyJWT(jwtStr)
+	r.True(strings.HasPrefix(err.Error(), "token is expired by"))
+	_, err = VerifyJWT(jwtStr, WithLeeway(10*time.Second))
+	r.NoError(err)
+}
@@ -0,0 +1,112 @@
+// Copyright (c) 2020 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package jwt
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/iotexproject/iotex-address/address"
+)
+
+const _bearer = "bearer "
+
+var (
+	// ErrMissingToken is returned when the request has no bearer token
+	ErrMissingToken = errors.New("missing bearer token")
+	// ErrInsufficientScope is returned when the JWT does not have the scope required
+	ErrInsufficientScope = errors.New("insufficient scope")
+	// ErrNoIssuers is returned when the middleware or the interceptors are created without the trusted issuers
+	ErrNoIssuers = errors.New("no trusted issuers")
+)
+
+type contextKey struct{}
+
+// NewContext returns a context carrying the verified JWT
+func NewContext(ctx context.Context, token *JWT) context.Context {
+	return context.WithValue(ctx, contextKey{}, token)
+}
+
+// FromContext returns the verified JWT in the context, put by the middleware or the interceptors
+func FromContext(ctx context.Context) (*JWT, bool) {
+	token, ok := ctx.Value(contextKey{}).(*JWT)
+	return token, ok && token != nil
+}
+
+// AddressFromContext returns the io address of the issuer of the verified JWT in the context
+func AddressFromContext(ctx context.Context) (address.Address, bool) {
+	token, ok := FromContext(ctx)
+	if !ok || token.Address == nil {
+		return nil, false
+	}
+	return token.Address, true
+}
+
+// Middleware returns the net/http middleware which verifies the bearer token in the Authorization header, and
+// requires the scope, one of CREATE, READ, UPDATE and DELETE, or none if it is empty. Wrap each route with the scope
+// it requires. The verified JWT is put into the context of the request, see FromContext and AddressFromContext.
+//
+// The token must be issued by one of the issuers, as in WithIssuers, since anyone can issue a valid token by their
+// own key. It fails if issuers is empty or has an invalid issuer.
+func Middleware(scope string, issuers []string, opts ...VerifyOption) (func(http.Handler) http.Handler, error) {
+	opts, err := withTrustedIssuers(issuers, opts)
+	if err != nil {
+		return nil, err
+	}
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			token, err := authenticate(r.Header.Get("Authorization"), scope, opts...)
+			switch {
+			case errors.Is(err, ErrInsufficientScope):
+				w.Header().Set("WWW-Authenticate", fmt.Sprintf(`Bearer error="insufficient_scope", scope="%s"`, scope))
+				http.Error(w, err.Error(), http.StatusForbidden)
+				return
+			case errors.Is(err, ErrMissingToken):
+				w.Header().Set("WWW-Authenticate", "Bearer")
+				http.Error(w, err.Error(), http.StatusUnauthorized)
+				return
+			case err != nil:
+				w.Header().Set("WWW-Authenticate", `Bearer error="invalid_token"`)
+				http.Error(w, err.Error(), http.StatusUnauthorized)
+				return
+			}
+			next.ServeHTTP(w, r.WithContext(NewContext(r.Context(), token)))
+		})
+	}, nil
+}
+
+// withTrustedIssuers checks the issuers, and returns the options accepting the tokens issued by them only
+func withTrustedIssuers(issuers []string, opts []VerifyOption) ([]VerifyOption, error) {
+	if len(issuers) == 0 {
+		return nil, ErrNoIssuers
+	}
+	trusted := WithIssuers(issuers...)
+	cfg := verifyConfig{}
+	trusted(&cfg)
+	if cfg.err != nil {
+		return nil, cfg.err
+	}
+	return append(append([]VerifyOption{}, opts...), trusted), nil
+}
+
+// authenticate verifies the token in the value of the Authorization header, and checks it has the scope
+func authenticate(authorization, scope string, opts ...VerifyOption) (*JWT, error) {
+	if len(authorization) <= len(_bearer) || !strings.EqualFold(authorization[:len(_bearer)], _bearer) {
+		return nil, ErrMissingToken
+	}
+	token, err := VerifyJWT(strings.TrimSpace(authorization[len(_bearer):]), opts...)
+	if err != nil {
+		return nil, err
+	}
+	if scope != "" && !token.HasScope(scope) {
+		return nil, ErrInsufficientScope
+	}
+	return token, nil
+}
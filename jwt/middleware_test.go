@@ -0,0 +1,161 @@
+// Copyright (c) 2020 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package jwt
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/iotexproject/go-pkgs/crypto"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+type testServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *testServerStream) Context() context.Context {
+	return s.ctx
+}
+
+func TestMiddleware(t *testing.T) {
+	r := require.New(t)
+
+	sk, err := crypto.GenerateKey()
+	r.NoError(err)
+	now := time.Now().Unix()
+	jwtStr, err := SignJWT(now, now+60, "sub", READ, sk, WithAudience("example.com"))
+	r.NoError(err)
+	// self-issued by an unknown key, with all the scopes
+	unknown, err := crypto.GenerateKey()
+	r.NoError(err)
+	forged, err := SignJWT(now, now+60, "sub", "Create Read Update Delete", unknown, WithAudience("example.com"))
+	r.NoError(err)
+
+	// the trusted issuers are required
+	_, err = Middleware(READ, nil)
+	r.Equal(ErrNoIssuers, err)
+	_, err = Middleware(READ, []string{"io1typo"})
+	r.Contains(err.Error(), "invalid issuer io1typo")
+	issuers := []string{sk.PublicKey().Address().String()}
+	middleware := func(scope string, opts ...VerifyOption) func(http.Handler) http.Handler {
+		m, err := Middleware(scope, issuers, opts...)
+		r.NoError(err)
+		return m
+	}
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		token, ok := FromContext(req.Context())
+		r.True(ok)
+		r.Equal("sub", token.Subject)
+		addr, ok := AddressFromContext(req.Context())
+		r.True(ok)
+		w.Write([]byte(addr.String()))
+	})
+	mux := http.NewServeMux()
+	mux.Handle("/read", middleware(READ, ExpectAudience("example.com"))(handler))
+	mux.Handle("/delete", middleware(DELETE, ExpectAudience("example.com"))(handler))
+	mux.Handle("/any", middleware("")(handler))
+
+	for _, v := range []struct {
+		path, authorization string
+		code                int
+		authenticate        string
+	}{
+		{"/read", "Bearer " + jwtStr, http.StatusOK, ""},
+		{"/read", "bearer " + jwtStr, http.StatusOK, ""},
+		{"/any", "Bearer " + jwtStr, http.StatusOK, ""},
+		{"/delete", "Bearer " + jwtStr, http.StatusForbidden, `Bearer error="insufficient_scope", scope="Delete"`},
+		{"/read", "", http.StatusUnauthorized, "Bearer"},
+		{"/read", "Basic dXNlcjpwYXNz", http.StatusUnauthorized, "Bearer"},
+		{"/read", "Bearer " + jwtStr + "x", http.StatusUnauthorized, `Bearer error="invalid_token"`},
+		{"/delete", "Bearer " + forged, http.StatusUnauthorized, `Bearer error="invalid_token"`},
+		{"/any", "Bearer " + forged, http.StatusUnauthorized, `Bearer error="invalid_token"`},
+	} {
+		req := httptest.NewRequest(http.MethodGet, v.path, nil)
+		if v.authorization != "" {
+			req.Header.Set("Authorization", v.authorization)
+		}
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, req)
+		r.Equal(v.code, rec.Code, v.path)
+		r.Equal(v.authenticate, rec.Header().Get("WWW-Authenticate"))
+		if v.code == http.StatusOK {
+			r.Equal(sk.PublicKey().Address().String(), rec.Body.String())
+		}
+	}
+}
+
+func TestServerInterceptor(t *testing.T) {
+	r := require.New(t)
+
+	sk, err := crypto.GenerateKey()
+	r.NoError(err)
+	now := time.Now().Unix()
+	jwtStr, err := SignJWT(now, now+60, "sub", READ+" "+UPDATE, sk)
+	r.NoError(err)
+	unknown, err := crypto.GenerateKey()
+	r.NoError(err)
+	forged, err := SignJWT(now, now+60, "sub", "Create Read Update Delete", unknown)
+	r.NoError(err)
+	scopes := MethodScopes{
+		"/test.Service/Get":    READ,
+		"/test.Service/Delete": DELETE,
+	}
+	_, err = UnaryServerInterceptor(scopes, nil)
+	r.Equal(ErrNoIssuers, err)
+	_, err = StreamServerInterceptor(scopes, []string{})
+	r.Equal(ErrNoIssuers, err)
+	issuers := []string{"0x" + sk.PublicKey().HexString()}
+	unary, err := UnaryServerInterceptor(scopes, issuers)
+	r.NoError(err)
+	stream, err := StreamServerInterceptor(scopes, issuers)
+	r.NoError(err)
+	handler := func(ctx context.Context, _ interface{}) (interface{}, error) {
+		addr, ok := AddressFromContext(ctx)
+		r.True(ok)
+		return addr.String(), nil
+	}
+	streamHandler := func(_ interface{}, ss grpc.ServerStream) error {
+		_, ok := FromContext(ss.Context())
+		r.True(ok)
+		return nil
+	}
+
+	for _, v := range []struct {
+		method, authorization string
+		code                  codes.Code
+	}{
+		{"/test.Service/Get", "Bearer " + jwtStr, codes.OK},
+		{"/test.Service/Other", "Bearer " + jwtStr, codes.OK},
+		{"/test.Service/Delete", "Bearer " + jwtStr, codes.PermissionDenied},
+		{"/test.Service/Get", "", codes.Unauthenticated},
+		{"/test.Service/Get", "Bearer x." + jwtStr, codes.Unauthenticated},
+		{"/test.Service/Delete", "Bearer " + forged, codes.Unauthenticated},
+		{"/test.Service/Other", "Bearer " + forged, codes.Unauthenticated},
+	} {
+		ctx := context.Background()
+		if v.authorization != "" {
+			ctx = metadata.NewIncomingContext(ctx, metadata.Pairs("authorization", v.authorization))
+		}
+		res, err := unary(ctx, nil, &grpc.UnaryServerInfo{FullMethod: v.method}, handler)
+		r.Equal(v.code, status.Code(err), v.method)
+		if v.code == codes.OK {
+			r.Equal(sk.PublicKey().Address().String(), res)
+		}
+		err = stream(nil, &testServerStream{ctx: ctx}, &grpc.StreamServerInfo{FullMethod: v.method}, streamHandler)
+		r.Equal(v.code, status.Code(err), v.method)
+	}
+}
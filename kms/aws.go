@@ -0,0 +1,58 @@
+// Copyright (c) 2020 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package kms
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/kms"
+	"github.com/aws/aws-sdk-go-v2/service/kms/types"
+	"github.com/pkg/errors"
+)
+
+type (
+	// AWSAPI is the part of the AWS KMS client used by the signer, implemented by *kms.Client
+	AWSAPI interface {
+		GetPublicKey(ctx context.Context, params *kms.GetPublicKeyInput, optFns ...func(*kms.Options)) (*kms.GetPublicKeyOutput, error)
+		Sign(ctx context.Context, params *kms.SignInput, optFns ...func(*kms.Options)) (*kms.SignOutput, error)
+	}
+
+	awsClient struct {
+		api AWSAPI
+	}
+)
+
+// NewAWSClient creates a Client of AWS KMS. The key ID can be the key ID, key ARN, alias name or alias ARN of a key
+// of spec ECC_SECG_P256K1.
+func NewAWSClient(api AWSAPI) Client {
+	return &awsClient{api}
+}
+
+func (c *awsClient) PublicKey(ctx context.Context, keyID string) ([]byte, error) {
+	out, err := c.api.GetPublicKey(ctx, &kms.GetPublicKeyInput{KeyId: aws.String(keyID)})
+	if err != nil {
+		return nil, err
+	}
+	if out.KeySpec != types.KeySpecEccSecgP256k1 {
+		return nil, errors.Errorf("key spec %s is not %s", out.KeySpec, types.KeySpecEccSecgP256k1)
+	}
+	return out.PublicKey, nil
+}
+
+func (c *awsClient) Sign(ctx context.Context, keyID string, digest []byte) ([]byte, error) {
+	out, err := c.api.Sign(ctx, &kms.SignInput{
+		KeyId:            aws.String(keyID),
+		Message:          digest,
+		MessageType:      types.MessageTypeDigest,
+		SigningAlgorithm: types.SigningAlgorithmSpecEcdsaSha256,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return out.Signature, nil
+}
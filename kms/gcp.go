@@ -0,0 +1,136 @@
+// Copyright (c) 2020 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package kms
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"encoding/pem"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// _gcpEndpoint is the endpoint of the GCP KMS REST API
+const _gcpEndpoint = "https://cloudkms.googleapis.com"
+
+type (
+	// GCPOption is an option of the GCP KMS client
+	GCPOption func(*gcpClient)
+
+	gcpClient struct {
+		endpoint string
+		client   *http.Client
+	}
+
+	gcpPublicKey struct {
+		Pem       string `json:"pem"`
+		Algorithm string `json:"algorithm"`
+	}
+
+	gcpDigest struct {
+		Sha256 []byte `json:"sha256"`
+	}
+
+	gcpSignRequest struct {
+		Digest gcpDigest `json:"digest"`
+	}
+
+	gcpSignResponse struct {
+		Signature []byte `json:"signature"`
+	}
+
+	gcpError struct {
+		Error struct {
+			Code    int    `json:"code"`
+			Message string `json:"message"`
+		} `json:"error"`
+	}
+)
+
+// WithGCPEndpoint sets the endpoint of the GCP KMS REST API, default is https://cloudkms.googleapis.com
+func WithGCPEndpoint(endpoint string) GCPOption {
+	return func(c *gcpClient) {
+		c.endpoint = strings.TrimSuffix(endpoint, "/")
+	}
+}
+
+// NewGCPClient creates a Client of GCP KMS, calling the REST API with the http.Client, which should add the OAuth2
+// token, such as the client of oauth2.NewClient with google.DefaultTokenSource. The key ID is the resource name of a
+// key version of algorithm EC_SIGN_SECP256K1_SHA256, projects/*/locations/*/keyRings/*/cryptoKeys/*/cryptoKeyVersions/*.
+func NewGCPClient(client *http.Client, opts ...GCPOption) Client {
+	c := &gcpClient{
+		endpoint: _gcpEndpoint,
+		client:   client,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+func (c *gcpClient) PublicKey(ctx context.Context, keyID string) ([]byte, error) {
+	var res gcpPublicKey
+	if err := c.do(ctx, http.MethodGet, "/v1/"+keyID+"/publicKey", nil, &res); err != nil {
+		return nil, err
+	}
+	if res.Algorithm != "EC_SIGN_SECP256K1_SHA256" {
+		return nil, errors.Errorf("key algorithm %s is not EC_SIGN_SECP256K1_SHA256", res.Algorithm)
+	}
+	block, _ := pem.Decode([]byte(res.Pem))
+	if block == nil || block.Type != "PUBLIC KEY" {
+		return nil, errors.New("invalid PEM public key")
+	}
+	return block.Bytes, nil
+}
+
+func (c *gcpClient) Sign(ctx context.Context, keyID string, digest []byte) ([]byte, error) {
+	var res gcpSignResponse
+	req := &gcpSignRequest{Digest: gcpDigest{Sha256: digest}}
+	if err := c.do(ctx, http.MethodPost, "/v1/"+keyID+":asymmetricSign", req, &res); err != nil {
+		return nil, err
+	}
+	return res.Signature, nil
+}
+
+func (c *gcpClient) do(ctx context.Context, method, path string, in, out interface{}) error {
+	var body io.Reader
+	if in != nil {
+		data, err := json.Marshal(in)
+		if err != nil {
+			return err
+		}
+		body = bytes.NewReader(data)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, c.endpoint+path, body)
+	if err != nil {
+		return err
+	}
+	if in != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		var e gcpError
+		if json.Unmarshal(data, &e) == nil && e.Error.Message != "" {
+			return errors.Errorf("gcp kms: %s (%d)", e.Error.Message, e.Error.Code)
+		}
+		return errors.Errorf("gcp kms: %s", resp.Status)
+	}
+	return json.Unmarshal(data, out)
+}
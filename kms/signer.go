@@ -0,0 +1,131 @@
+// Copyright (c) 2020 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+// Package kms signs with secp256k1 keys held by cloud KMS, such as AWS KMS keys of spec ECC_SECG_P256K1 and GCP KMS
+// keys of algorithm EC_SIGN_SECP256K1_SHA256, so the keys are never exported.
+package kms
+
+import (
+	"context"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"math/big"
+	"time"
+
+	"github.com/iotexproject/go-pkgs/crypto"
+	"github.com/iotexproject/iotex-address/address"
+	"github.com/pkg/errors"
+
+	"github.com/iotexproject/iotex-antenna-go/v2/account"
+)
+
+// _signTimeout is the timeout of a Sign call to KMS
+const _signTimeout = 30 * time.Second
+
+var (
+	_oidPublicKeyECDSA = asn1.ObjectIdentifier{1, 2, 840, 10045, 2, 1}
+	_oidSecp256k1      = asn1.ObjectIdentifier{1, 3, 132, 0, 10}
+)
+
+type (
+	// Client is the client of a KMS
+	Client interface {
+		// PublicKey returns the DER-encoded SubjectPublicKeyInfo of the key
+		PublicKey(ctx context.Context, keyID string) ([]byte, error)
+		// Sign signs the 32-byte digest with the key, and returns the DER-encoded ECDSA signature
+		Sign(ctx context.Context, keyID string, digest []byte) ([]byte, error)
+	}
+
+	signer struct {
+		client    Client
+		keyID     string
+		address   address.Address
+		publicKey crypto.PublicKey
+	}
+
+	publicKeyInfo struct {
+		Algorithm pkix.AlgorithmIdentifier
+		PublicKey asn1.BitString
+	}
+
+	ecdsaSignature struct {
+		R, S *big.Int
+	}
+)
+
+// NewSigner creates an account.Signer of the KMS key, which can be used by iotex.NewSignerClient and
+// account.SignMessage. The public key is fetched from KMS.
+func NewSigner(ctx context.Context, client Client, keyID string) (account.Signer, error) {
+	der, err := client.PublicKey(ctx, keyID)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get public key of %s", keyID)
+	}
+	pk, err := ParsePublicKey(der)
+	if err != nil {
+		return nil, err
+	}
+	addr, err := address.FromBytes(pk.Hash())
+	if err != nil {
+		return nil, err
+	}
+	return &signer{
+		client:    client,
+		keyID:     keyID,
+		address:   addr,
+		publicKey: pk,
+	}, nil
+}
+
+func (s *signer) Address() address.Address { return s.address }
+
+func (s *signer) PublicKey() crypto.PublicKey { return s.publicKey }
+
+func (s *signer) SignHash(digest []byte) ([]byte, error) {
+	if len(digest) != 32 {
+		return nil, errors.Errorf("wrong size for digest: got %d, want 32", len(digest))
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), _signTimeout)
+	defer cancel()
+	der, err := s.client.Sign(ctx, s.keyID, digest)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to sign with %s", s.keyID)
+	}
+	return ToRecoverableSignature(der, digest, s.publicKey)
+}
+
+// ParsePublicKey parses the DER-encoded SubjectPublicKeyInfo of a secp256k1 key
+func ParsePublicKey(der []byte) (crypto.PublicKey, error) {
+	var info publicKeyInfo
+	rest, err := asn1.Unmarshal(der, &info)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid public key")
+	}
+	if len(rest) > 0 {
+		return nil, errors.New("invalid public key: trailing data")
+	}
+	var curve asn1.ObjectIdentifier
+	if !info.Algorithm.Algorithm.Equal(_oidPublicKeyECDSA) {
+		return nil, errors.Errorf("public key algorithm %s is not ECDSA", info.Algorithm.Algorithm)
+	}
+	if _, err := asn1.Unmarshal(info.Algorithm.Parameters.FullBytes, &curve); err != nil || !curve.Equal(_oidSecp256k1) {
+		return nil, errors.New("public key curve is not secp256k1")
+	}
+	return crypto.BytesToPublicKey(info.PublicKey.RightAlign())
+}
+
+// ToRecoverableSignature converts the DER-encoded ECDSA signature of the digest to the 65-byte signature
+// [R || S || V] of IoTeX, as account.ToRecoverableSignature
+func ToRecoverableSignature(der, digest []byte, pk crypto.PublicKey) ([]byte, error) {
+	var sig ecdsaSignature
+	rest, err := asn1.Unmarshal(der, &sig)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid signature")
+	}
+	if len(rest) > 0 {
+		return nil, errors.New("invalid signature: trailing data")
+	}
+	return account.ToRecoverableSignature(sig.R, sig.S, digest, pk)
+}
@@ -0,0 +1,219 @@
+// Copyright (c) 2020 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package kms
+
+import (
+	"context"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/kms"
+	ethCrypto "github.com/ethereum/go-ethereum/crypto"
+	"github.com/golang/mock/gomock"
+	"github.com/iotexproject/go-pkgs/crypto"
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/iotexproject/iotex-proto/golang/iotexapi"
+	"github.com/iotexproject/iotex-proto/golang/iotexapi/mock_iotexapi"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+
+	"github.com/iotexproject/iotex-antenna-go/v2/account"
+	"github.com/iotexproject/iotex-antenna-go/v2/iotex"
+)
+
+const _keyID = "projects/p/locations/global/keyRings/r/cryptoKeys/treasury/cryptoKeyVersions/1"
+
+// fakeKMS holds a key, and returns signatures in DER, with high S every other time as KMS may do
+type fakeKMS struct {
+	key   crypto.PrivateKey
+	count int
+}
+
+func newFakeKMS(t *testing.T) *fakeKMS {
+	sk, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	return &fakeKMS{key: sk}
+}
+
+func (f *fakeKMS) publicKeyDER() []byte {
+	params, _ := asn1.Marshal(_oidSecp256k1)
+	der, _ := asn1.Marshal(publicKeyInfo{
+		Algorithm: pkix.AlgorithmIdentifier{
+			Algorithm:  _oidPublicKeyECDSA,
+			Parameters: asn1.RawValue{FullBytes: params},
+		},
+		PublicKey: asn1.BitString{Bytes: f.key.PublicKey().Bytes(), BitLength: 8 * len(f.key.PublicKey().Bytes())},
+	})
+	return der
+}
+
+func (f *fakeKMS) sign(digest []byte) []byte {
+	sig, _ := f.key.Sign(digest)
+	r, s := new(big.Int).SetBytes(sig[:32]), new(big.Int).SetBytes(sig[32:64])
+	if f.count++; f.count%2 == 0 {
+		s.Sub(ethCrypto.S256().Params().N, s)
+	}
+	der, _ := asn1.Marshal(ecdsaSignature{r, s})
+	return der
+}
+
+// ServeHTTP serves the GetPublicKey and Sign operations of the AWS KMS JSON protocol
+func (f *fakeKMS) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		KeyId       string
+		Message     []byte
+		MessageType string
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.KeyId != _keyID {
+		w.Header().Set("X-Amzn-ErrorType", "NotFoundException")
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"__type":"NotFoundException","message":"key not found"}`))
+		return
+	}
+	var res interface{}
+	switch r.Header.Get("X-Amz-Target") {
+	case "TrentService.GetPublicKey":
+		res = map[string]interface{}{
+			"KeyId":     req.KeyId,
+			"KeySpec":   "ECC_SECG_P256K1",
+			"KeyUsage":  "SIGN_VERIFY",
+			"PublicKey": f.publicKeyDER(),
+		}
+	case "TrentService.Sign":
+		res = map[string]interface{}{
+			"KeyId":            req.KeyId,
+			"Signature":        f.sign(req.Message),
+			"SigningAlgorithm": "ECDSA_SHA_256",
+		}
+	}
+	w.Header().Set("Content-Type", "application/x-amz-json-1.1")
+	json.NewEncoder(w).Encode(res)
+}
+
+// gcpHandler serves the publicKey and asymmetricSign methods of the GCP KMS REST API
+func (f *fakeKMS) gcpHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path := strings.TrimPrefix(r.URL.Path, "/v1/")
+		switch {
+		case path == _keyID+"/publicKey":
+			json.NewEncoder(w).Encode(&gcpPublicKey{
+				Pem:       string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: f.publicKeyDER()})),
+				Algorithm: "EC_SIGN_SECP256K1_SHA256",
+			})
+		case path == _keyID+":asymmetricSign":
+			var req gcpSignRequest
+			json.NewDecoder(r.Body).Decode(&req)
+			json.NewEncoder(w).Encode(&gcpSignResponse{Signature: f.sign(req.Digest.Sha256)})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"error":{"code":404,"message":"not found"}}`))
+		}
+	})
+}
+
+// newSendAPI returns the API accepting the actions of 31337 signed by the signer
+func newSendAPI(t *testing.T, s account.Signer, times int) iotexapi.APIServiceClient {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+	api := mock_iotexapi.NewMockAPIServiceClient(ctrl)
+	api.EXPECT().SendAction(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, in *iotexapi.SendActionRequest, _ ...grpc.CallOption) (*iotexapi.SendActionResponse, error) {
+			act := in.GetAction()
+			require.NoError(t, iotex.VerifyAction(act, iotex.EVMNetworkID(31337)))
+			pk, err := crypto.BytesToPublicKey(act.GetSenderPubKey())
+			require.NoError(t, err)
+			require.Equal(t, s.Address().String(), pk.Address().String())
+			h, err := iotex.ActionHash(act, iotex.EVMNetworkID(31337))
+			require.NoError(t, err)
+			return &iotexapi.SendActionResponse{ActionHash: hex.EncodeToString(h[:])}, nil
+		}).Times(times)
+	return api
+}
+
+func TestAWSSigner(t *testing.T) {
+	require := require.New(t)
+	f := newFakeKMS(t)
+	ts := httptest.NewServer(f)
+	defer ts.Close()
+	client := NewAWSClient(kms.New(kms.Options{
+		Region:       "us-east-1",
+		BaseEndpoint: aws.String(ts.URL),
+		Credentials:  aws.AnonymousCredentials{},
+	}))
+
+	ctx := context.Background()
+	_, err := NewSigner(ctx, client, "alias/unknown")
+	require.Error(err)
+	s, err := NewSigner(ctx, client, _keyID)
+	require.NoError(err)
+	require.Equal(f.key.PublicKey().HexString(), s.PublicKey().HexString())
+
+	// transfers are signed by KMS in both encodings
+	to, err := account.NewAccount()
+	require.NoError(err)
+	api := newSendAPI(t, s, 2)
+	for _, opts := range [][]iotex.AuthedClientOption{nil, {iotex.WithEthereumRLP()}} {
+		_, err := iotex.NewSignerClient(api, 31337, s, opts...).Transfer(to.Address(), big.NewInt(1000)).
+			SetNonce(1).SetGasLimit(10000).SetGasPrice(big.NewInt(1e12)).Call(ctx)
+		require.NoError(err)
+	}
+}
+
+func TestGCPSigner(t *testing.T) {
+	require := require.New(t)
+	f := newFakeKMS(t)
+	ts := httptest.NewServer(f.gcpHandler())
+	defer ts.Close()
+	client := NewGCPClient(ts.Client(), WithGCPEndpoint(ts.URL))
+
+	ctx := context.Background()
+	_, err := NewSigner(ctx, client, "projects/p/unknown")
+	require.Contains(err.Error(), "not found")
+	s, err := NewSigner(ctx, client, _keyID)
+	require.NoError(err)
+	for i := 0; i < 4; i++ {
+		sig, err := account.SignMessage(s, []byte("hello"))
+		require.NoError(err)
+		h := account.HashMessage([]byte("hello"))
+		addr, err := account.RecoverAddress(h[:], sig)
+		require.NoError(err)
+		require.Equal(s.Address().String(), addr.String())
+	}
+}
+
+func TestToRecoverableSignature(t *testing.T) {
+	require := require.New(t)
+	f := newFakeKMS(t)
+	pk, err := ParsePublicKey(f.publicKeyDER())
+	require.NoError(err)
+	require.Equal(f.key.PublicKey().HexString(), pk.HexString())
+
+	h := hash.Hash256b([]byte("hello"))
+	expected, err := f.key.Sign(h[:])
+	require.NoError(err)
+	for i := 0; i < 2; i++ {
+		// both the low-S and high-S signatures are normalized to the low-S one
+		sig, err := ToRecoverableSignature(f.sign(h[:]), h[:], pk)
+		require.NoError(err)
+		require.Equal(expected, sig)
+	}
+	other, err := crypto.GenerateKey()
+	require.NoError(err)
+	_, err = ToRecoverableSignature(f.sign(h[:]), h[:], other.PublicKey())
+	require.Error(err)
+	_, err = ToRecoverableSignature([]byte{0x30, 0x00}, h[:], pk)
+	require.Error(err)
+}
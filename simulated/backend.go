@@ -0,0 +1,314 @@
+// Copyright (c) 2020 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+// Package simulated provides an in-memory IoTeX chain for tests. It serves iotexapi.APIServiceServer over an
+// in-process gRPC connection, keeps balances and nonces, executes contracts with the EVM, and mines a block on each
+// Commit. Staking is simplified: there are no minimum amounts, maturity or fees, and votes are not weighted.
+//
+// It is a separate module, so the state and the EVM of go-ethereum are not dependencies of the SDK.
+package simulated
+
+import (
+	"context"
+	"math/big"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/golang/protobuf/proto"
+	"github.com/holiman/uint256"
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/iotexproject/iotex-address/address"
+	"github.com/iotexproject/iotex-proto/golang/iotexapi"
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/iotexproject/iotex-antenna-go/v2/iotex"
+)
+
+const (
+	// DefaultBlockGasLimit is the gas limit of a block
+	DefaultBlockGasLimit = 50000000
+	// DefaultBlockInterval is the time between two blocks
+	DefaultBlockInterval = 5 * time.Second
+
+	_bufSize = 1 << 20
+)
+
+type (
+	// GenesisAlloc is the initial balances of io addresses
+	GenesisAlloc map[string]*big.Int
+
+	// Option is an option of the Backend
+	Option func(*Backend)
+
+	// Backend is an in-memory IoTeX chain
+	Backend struct {
+		// commitMutex serializes the commits, so the blocks are sent to the subscribers in order
+		commitMutex   sync.Mutex
+		mutex         sync.RWMutex
+		chainID       uint32
+		evmConfig     *params.ChainConfig
+		gasLimit      uint64
+		gasPrice      *big.Int
+		blockInterval time.Duration
+
+		state      *state.StateDB
+		blocks     []*block
+		pending    []*pendingAction
+		actions    map[hash.Hash256]*actionInfo
+		buckets    []*iotextypes.VoteBucket
+		candidates map[string]*iotextypes.CandidateV2
+
+		subs     map[chan *block]struct{}
+		listener *bufconn.Listener
+		server   *grpc.Server
+		conn     *grpc.ClientConn
+	}
+
+	block struct {
+		height    uint64
+		hash      hash.Hash256
+		timestamp time.Time
+		actions   []*iotextypes.Action
+		receipts  []*iotextypes.Receipt
+	}
+
+	pendingAction struct {
+		action *iotextypes.Action
+		hash   hash.Hash256
+		sender address.Address
+	}
+
+	actionInfo struct {
+		pendingAction
+		block   *block
+		index   int
+		receipt *iotextypes.Receipt
+	}
+)
+
+// WithBlockGasLimit sets the gas limit of a block, default is DefaultBlockGasLimit
+func WithBlockGasLimit(gasLimit uint64) Option {
+	return func(b *Backend) {
+		b.gasLimit = gasLimit
+	}
+}
+
+// WithGasPrice sets the gas price returned by SuggestGasPrice, default is 1 Qev
+func WithGasPrice(gasPrice *big.Int) Option {
+	return func(b *Backend) {
+		b.gasPrice = gasPrice
+	}
+}
+
+// WithBlockInterval sets the time between the timestamps of two blocks, default is DefaultBlockInterval
+func WithBlockInterval(d time.Duration) Option {
+	return func(b *Backend) {
+		b.blockInterval = d
+	}
+}
+
+// NewBackend creates a simulated chain with the given chain ID and initial balances, and starts serving the API.
+func NewBackend(chainID uint32, alloc GenesisAlloc, opts ...Option) (*Backend, error) {
+	st, err := state.New(types.EmptyRootHash, state.NewDatabase(rawdb.NewMemoryDatabase()), nil)
+	if err != nil {
+		return nil, err
+	}
+	b := &Backend{
+		chainID:       chainID,
+		gasLimit:      DefaultBlockGasLimit,
+		gasPrice:      big.NewInt(1000000000000),
+		blockInterval: DefaultBlockInterval,
+		state:         st,
+		blocks:        []*block{{timestamp: time.Now().Truncate(time.Second)}},
+		actions:       make(map[hash.Hash256]*actionInfo),
+		candidates:    make(map[string]*iotextypes.CandidateV2),
+		subs:          make(map[chan *block]struct{}),
+	}
+	for _, opt := range opts {
+		opt(b)
+	}
+	cfg := *params.AllEthashProtocolChanges
+	cfg.ChainID = big.NewInt(int64(iotex.EVMNetworkID(chainID)))
+	cfg.ShanghaiTime = new(uint64)
+	b.evmConfig = &cfg
+
+	for s, balance := range alloc {
+		addr, err := address.FromString(s)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid genesis address %s", s)
+		}
+		value, overflow := uint256.FromBig(balance)
+		if overflow || balance.Sign() < 0 {
+			return nil, errors.Errorf("invalid genesis balance %s of %s", balance, s)
+		}
+		b.state.AddBalance(common.BytesToAddress(addr.Bytes()), value)
+	}
+	b.state.Finalise(true)
+
+	b.listener = bufconn.Listen(_bufSize)
+	b.server = grpc.NewServer()
+	iotexapi.RegisterAPIServiceServer(b.server, &server{b: b})
+	go b.server.Serve(b.listener)
+	b.conn, err = grpc.Dial("bufnet",
+		grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) { return b.listener.Dial() }),
+		grpc.WithInsecure(),
+	)
+	if err != nil {
+		b.server.Stop()
+		return nil, err
+	}
+	return b, nil
+}
+
+// Client returns an API client connected to the simulated chain
+func (b *Backend) Client() iotexapi.APIServiceClient {
+	return iotexapi.NewAPIServiceClient(b.conn)
+}
+
+// ChainID returns the chain ID of the simulated chain
+func (b *Backend) ChainID() uint32 {
+	return b.chainID
+}
+
+// Close stops serving the API
+func (b *Backend) Close() error {
+	err := b.conn.Close()
+	b.server.Stop()
+	return err
+}
+
+// Height returns the height of the latest block
+func (b *Backend) Height() uint64 {
+	b.mutex.RLock()
+	defer b.mutex.RUnlock()
+	return b.tip().height
+}
+
+// Balance returns the balance of the address
+func (b *Backend) Balance(addr address.Address) *big.Int {
+	// the state is not safe for concurrent reads
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	return b.state.GetBalance(common.BytesToAddress(addr.Bytes())).ToBig()
+}
+
+// Bucket returns the staking bucket of the index, or nil if it does not exist or has been withdrawn
+func (b *Backend) Bucket(index uint64) *iotextypes.VoteBucket {
+	b.mutex.RLock()
+	defer b.mutex.RUnlock()
+	if index >= uint64(len(b.buckets)) || b.buckets[index] == nil {
+		return nil
+	}
+	return proto.Clone(b.buckets[index]).(*iotextypes.VoteBucket)
+}
+
+// Candidate returns the candidate of the name, or nil if it does not exist. TotalWeightedVotes is the sum of the
+// staked amounts of the buckets voting for the candidate and not unstaked.
+func (b *Backend) Candidate(name string) *iotextypes.CandidateV2 {
+	b.mutex.RLock()
+	defer b.mutex.RUnlock()
+	c, ok := b.candidates[name]
+	if !ok {
+		return nil
+	}
+	c = proto.Clone(c).(*iotextypes.CandidateV2)
+	votes := new(big.Int)
+	for _, bucket := range b.buckets {
+		if bucket == nil || bucket.GetCandidateAddress() != c.GetOwnerAddress() || bucket.GetUnstakeStartTime() != nil {
+			continue
+		}
+		amount, _ := new(big.Int).SetString(bucket.GetStakedAmount(), 10)
+		votes.Add(votes, amount)
+	}
+	c.TotalWeightedVotes = votes.String()
+	return c
+}
+
+// Commit mines the pending actions into a new block, and returns the block height
+func (b *Backend) Commit() uint64 {
+	b.commitMutex.Lock()
+	defer b.commitMutex.Unlock()
+
+	b.mutex.Lock()
+	prev := b.tip()
+	blk := &block{
+		height:    prev.height + 1,
+		timestamp: prev.timestamp.Add(b.blockInterval),
+	}
+	data := append(prev.hash[:], uint64ToBytes(blk.height)...)
+	for _, p := range b.pending {
+		data = append(data, p.hash[:]...)
+	}
+	blk.hash = hash.Hash256b(data)
+
+	var gasUsed uint64
+	for i, p := range b.pending {
+		r := b.execute(blk, i, p)
+		// the refund counter and the journal are per action
+		b.state.Finalise(true)
+		gasUsed += r.GetGasConsumed()
+		blk.actions = append(blk.actions, p.action)
+		blk.receipts = append(blk.receipts, r)
+		b.actions[p.hash] = &actionInfo{
+			pendingAction: *p,
+			block:         blk,
+			index:         i,
+			receipt:       r,
+		}
+	}
+	b.pending = nil
+	b.blocks = append(b.blocks, blk)
+	subs := make([]chan *block, 0, len(b.subs))
+	for sub := range b.subs {
+		subs = append(subs, sub)
+	}
+	b.mutex.Unlock()
+
+	for _, sub := range subs {
+		select {
+		case sub <- blk:
+		default:
+			// the subscriber is too slow, drop it
+			if b.unsubscribe(sub) {
+				close(sub)
+			}
+		}
+	}
+	return blk.height
+}
+
+func (b *Backend) tip() *block {
+	return b.blocks[len(b.blocks)-1]
+}
+
+func (b *Backend) subscribe() chan *block {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	sub := make(chan *block, 64)
+	b.subs[sub] = struct{}{}
+	return sub
+}
+
+func (b *Backend) unsubscribe(sub chan *block) bool {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	_, ok := b.subs[sub]
+	delete(b.subs, sub)
+	return ok
+}
+
+func uint64ToBytes(v uint64) []byte {
+	return new(big.Int).SetUint64(v).FillBytes(make([]byte, 8))
+}
@@ -0,0 +1,444 @@
+// Copyright (c) 2020 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package simulated
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"math/big"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/iotexproject/iotex-address/address"
+	"github.com/iotexproject/iotex-proto/golang/iotexapi"
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/iotexproject/iotex-antenna-go/v2/account"
+	"github.com/iotexproject/iotex-antenna-go/v2/iotex"
+	"github.com/iotexproject/iotex-antenna-go/v2/utils/wait"
+)
+
+const (
+	_chainID = 31337
+
+	_setGetABI = `[{"constant":false,"inputs":[{"name":"x","type":"uint256"}],"name":"set","outputs":[],"payable":false,"stateMutability":"nonpayable","type":"function"},{"constant":true,"inputs":[],"name":"get","outputs":[{"name":"","type":"uint256"}],"payable":false,"stateMutability":"view","type":"function"},{"inputs":[{"name":"_x","type":"uint256"}],"payable":false,"stateMutability":"nonpayable","type":"constructor"}]`
+	_setGetBin = "608060405234801561001057600080fd5b506040516020806100f2833981016040525160005560bf806100336000396000f30060806040526004361060485763ffffffff7c010000000000000000000000000000000000000000000000000000000060003504166360fe47b18114604d5780636d4ce63c146064575b600080fd5b348015605857600080fd5b5060626004356088565b005b348015606f57600080fd5b506076608d565b60408051918252519081900360200190f35b600055565b600054905600a165627a7a723058208d4f6c9737f34d9b28ef070baa8127c0876757fbf6f3945a7ea8d4387ca156590029"
+
+	// log(x) emits a log with topic x, and reverts if x is 0
+	_logABI = `[{"inputs":[{"name":"x","type":"uint256"}],"name":"log","outputs":[],"stateMutability":"nonpayable","type":"function"}]`
+	_logBin = "601380600b6000396000f360043580600c5760006000fd5b60006000a100"
+
+	// fail(data) reverts with the data
+	_failABI = `[{"inputs":[{"name":"data","type":"bytes"}],"name":"fail","outputs":[],"stateMutability":"nonpayable","type":"function"}]`
+	_failBin = "600c80600b6000396000f36024358060446000376000fd"
+)
+
+func newTestBackend(t *testing.T, accounts ...account.Account) *Backend {
+	alloc := GenesisAlloc{}
+	for _, acc := range accounts {
+		alloc[acc.Address().String()] = new(big.Int).Mul(big.NewInt(1000), big.NewInt(1e18))
+	}
+	b, err := NewBackend(_chainID, alloc, WithBlockInterval(time.Second))
+	require.NoError(t, err)
+	t.Cleanup(func() { b.Close() })
+	return b
+}
+
+func commitAndWait(t *testing.T, b *Backend, h hash.Hash256) *iotextypes.Receipt {
+	b.Commit()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	r, err := wait.WaitForReceipt(ctx, b.Client(), h, wait.WithInterval(10*time.Millisecond))
+	// the failed receipts are checked by the tests
+	var receiptErr *wait.ReceiptError
+	if !errors.As(err, &receiptErr) {
+		require.NoError(t, err)
+	}
+	return r
+}
+
+func TestTransfer(t *testing.T) {
+	require := require.New(t)
+	acc, err := account.NewAccount()
+	require.NoError(err)
+	to, err := account.NewAccount()
+	require.NoError(err)
+	b := newTestBackend(t, acc)
+	require.EqualValues(_chainID, b.ChainID())
+
+	c := iotex.NewAuthedClient(b.Client(), _chainID, acc)
+	h, err := c.Transfer(to.Address(), big.NewInt(1e18)).Call(context.Background())
+	require.NoError(err)
+	// the nonce is taken from the pending actions
+	h2, err := c.Transfer(to.Address(), big.NewInt(1e18)).Call(context.Background())
+	require.NoError(err)
+	r := commitAndWait(t, b, h)
+	require.EqualValues(iotextypes.ReceiptStatus_Success, r.GetStatus())
+	require.EqualValues(10000, r.GetGasConsumed())
+	require.EqualValues(1, r.GetBlkHeight())
+	r, err = wait.WaitForReceipt(context.Background(), b.Client(), h2)
+	require.NoError(err)
+	require.EqualValues(1, r.GetBlkHeight())
+	require.EqualValues(1, b.Height())
+	require.Equal(big.NewInt(2e18), b.Balance(to.Address()))
+	fee := new(big.Int).Mul(big.NewInt(20000), big.NewInt(1000000000000))
+	expected := new(big.Int).Mul(big.NewInt(1000), big.NewInt(1e18))
+	expected.Sub(expected, big.NewInt(2e18)).Sub(expected, fee)
+	require.Equal(expected, b.Balance(acc.Address()))
+
+	// the nonce is checked
+	_, err = c.Transfer(to.Address(), big.NewInt(1)).SetNonce(10).Call(context.Background())
+	require.Error(err)
+	require.Contains(err.Error(), "nonce too high")
+	// a watch-only account cannot send
+	_, err = iotex.NewAuthedClient(b.Client(), _chainID, account.AddressToAccount(to.Address())).
+		Transfer(acc.Address(), big.NewInt(1)).SetGasLimit(10000).Call(context.Background())
+	require.Error(err)
+	// the amounts out of the range of uint256 are rejected
+	huge := new(big.Int).Lsh(big.NewInt(1), 256)
+	_, err = c.Transfer(to.Address(), huge).SetGasLimit(10000).Call(context.Background())
+	require.Error(err)
+	require.Contains(err.Error(), "exceeds 256 bits")
+	_, err = c.Transfer(to.Address(), big.NewInt(-1)).SetGasLimit(10000).Call(context.Background())
+	require.Error(err)
+	require.Contains(err.Error(), "invalid amount")
+	_, err = c.Transfer(to.Address(), big.NewInt(1)).SetGasLimit(10000).SetGasPrice(huge).Call(context.Background())
+	require.Error(err)
+	require.Contains(err.Error(), "exceeds 256 bits")
+	_, err = b.Client().ReadContract(context.Background(), &iotexapi.ReadContractRequest{
+		Execution:     &iotextypes.Execution{Amount: huge.String(), Contract: to.Address().String()},
+		CallerAddress: acc.Address().String(),
+	})
+	require.Equal(codes.InvalidArgument, status.Code(err))
+
+	// signed by an Ethereum wallet
+	rlp := iotex.NewAuthedClient(b.Client(), _chainID, acc, iotex.WithEthereumRLP())
+	h, err = rlp.Transfer(to.Address(), big.NewInt(1e18)).Call(context.Background())
+	require.NoError(err)
+	r = commitAndWait(t, b, h)
+	require.EqualValues(iotextypes.ReceiptStatus_Success, r.GetStatus())
+	require.Equal(big.NewInt(3e18), b.Balance(to.Address()))
+
+	res, err := b.Client().GetActions(context.Background(), &iotexapi.GetActionsRequest{
+		Lookup: &iotexapi.GetActionsRequest_ByHash{
+			ByHash: &iotexapi.GetActionByHashRequest{ActionHash: hex.EncodeToString(h[:])},
+		},
+	})
+	require.NoError(err)
+	require.Equal(acc.Address().String(), res.GetActionInfo()[0].GetSender())
+	require.Equal(iotextypes.Encoding_ETHEREUM_RLP, res.GetActionInfo()[0].GetAction().GetEncoding())
+}
+
+func TestRemoteSigner(t *testing.T) {
+	require := require.New(t)
+	acc, err := account.NewAccount()
+	require.NoError(err)
+	to, err := account.NewAccount()
+	require.NoError(err)
+	b := newTestBackend(t, acc)
+	local, err := account.AsSigner(acc)
+	require.NoError(err)
+	ts := httptest.NewServer(account.NewSignerHandler("", local))
+	defer ts.Close()
+	s, err := account.NewRemoteSigner(context.Background(), account.NewHTTPSignerClient(ts.URL), acc.Address())
+	require.NoError(err)
+
+	for _, opts := range [][]iotex.AuthedClientOption{nil, {iotex.WithEthereumRLP()}} {
+		c := iotex.NewSignerClient(b.Client(), _chainID, s, opts...)
+		h, err := c.Transfer(to.Address(), big.NewInt(1e18)).Call(context.Background())
+		require.NoError(err)
+		r := commitAndWait(t, b, h)
+		require.EqualValues(iotextypes.ReceiptStatus_Success, r.GetStatus())
+	}
+	require.Equal(big.NewInt(2e18), b.Balance(to.Address()))
+}
+
+func TestContract(t *testing.T) {
+	require := require.New(t)
+	acc, err := account.NewAccount()
+	require.NoError(err)
+	b := newTestBackend(t, acc)
+	c := iotex.NewAuthedClient(b.Client(), _chainID, acc)
+	ctx := context.Background()
+
+	setGetABI, err := abi.JSON(strings.NewReader(_setGetABI))
+	require.NoError(err)
+	data, err := hex.DecodeString(_setGetBin)
+	require.NoError(err)
+	h, err := c.DeployContract(data).SetArgs(setGetABI, big.NewInt(10)).Call(ctx)
+	require.NoError(err)
+	r := commitAndWait(t, b, h)
+	require.EqualValues(iotextypes.ReceiptStatus_Success, r.GetStatus())
+	contract, err := address.FromString(r.GetContractAddress())
+	require.NoError(err)
+
+	ret, err := c.ReadOnlyContract(contract, setGetABI).Read("get").Call(ctx)
+	require.NoError(err)
+	x, err := ret.Unmarshal()
+	require.NoError(err)
+	require.EqualValues(10, x[0].(*big.Int).Int64())
+
+	h, err = c.Contract(contract, setGetABI).Execute("set", big.NewInt(20)).Call(ctx)
+	require.NoError(err)
+	r = commitAndWait(t, b, h)
+	require.EqualValues(iotextypes.ReceiptStatus_Success, r.GetStatus())
+	ret, err = c.ReadOnlyContract(contract, setGetABI).Read("get").Call(ctx)
+	require.NoError(err)
+	x, err = ret.Unmarshal()
+	require.NoError(err)
+	require.EqualValues(20, x[0].(*big.Int).Int64())
+
+	// logs and reverts
+	logABI, err := abi.JSON(strings.NewReader(_logABI))
+	require.NoError(err)
+	data, err = hex.DecodeString(_logBin)
+	require.NoError(err)
+	h, err = c.DeployContract(data).Call(ctx)
+	require.NoError(err)
+	r = commitAndWait(t, b, h)
+	logContract, err := address.FromString(r.GetContractAddress())
+	require.NoError(err)
+
+	h, err = c.Contract(logContract, logABI).Execute("log", big.NewInt(7)).Call(ctx)
+	require.NoError(err)
+	r = commitAndWait(t, b, h)
+	require.EqualValues(iotextypes.ReceiptStatus_Success, r.GetStatus())
+	require.Len(r.GetLogs(), 1)
+	require.Equal(logContract.String(), r.GetLogs()[0].GetContractAddress())
+	require.Equal(big.NewInt(7).FillBytes(make([]byte, 32)), r.GetLogs()[0].GetTopics()[0])
+
+	logs, err := c.GetLogs(&iotexapi.GetLogsRequest{
+		Filter: &iotexapi.LogsFilter{Address: []string{logContract.String()}},
+		Lookup: &iotexapi.GetLogsRequest_ByRange{ByRange: &iotexapi.GetLogsByRange{FromBlock: 1, ToBlock: b.Height()}},
+	}).Call(ctx)
+	require.NoError(err)
+	require.Len(logs.GetLogs(), 1)
+	require.Equal(h[:], logs.GetLogs()[0].GetActHash())
+	logs, err = c.GetLogs(&iotexapi.GetLogsRequest{
+		Filter: &iotexapi.LogsFilter{Topics: []*iotexapi.Topics{{Topic: [][]byte{make([]byte, 32)}}}},
+		Lookup: &iotexapi.GetLogsRequest_ByRange{ByRange: &iotexapi.GetLogsByRange{FromBlock: 1, ToBlock: b.Height()}},
+	}).Call(ctx)
+	require.NoError(err)
+	require.Empty(logs.GetLogs())
+
+	// the estimation fails on revert
+	_, err = c.Contract(logContract, logABI).Execute("log", big.NewInt(0)).Call(ctx)
+	require.Error(err)
+	h, err = c.Contract(logContract, logABI).Execute("log", big.NewInt(0)).SetGasLimit(100000).Call(ctx)
+	require.NoError(err)
+	r = commitAndWait(t, b, h)
+	require.EqualValues(iotextypes.ReceiptStatus_ErrExecutionReverted, r.GetStatus())
+	require.Empty(r.GetLogs())
+
+	// the revert message is written as the node does
+	failABI, err := abi.JSON(strings.NewReader(_failABI))
+	require.NoError(err)
+	data, err = hex.DecodeString(_failBin)
+	require.NoError(err)
+	h, err = c.DeployContract(data).Call(ctx)
+	require.NoError(err)
+	r = commitAndWait(t, b, h)
+	failContract, err := address.FromString(r.GetContractAddress())
+	require.NoError(err)
+	stringType, err := abi.NewType("string", "", nil)
+	require.NoError(err)
+	reason, err := abi.Arguments{{Type: stringType}}.Pack("not allowed")
+	require.NoError(err)
+	panicData, err := hex.DecodeString("4e487b710000000000000000000000000000000000000000000000000000000000000011")
+	require.NoError(err)
+	for _, v := range []struct {
+		data []byte
+		msg  string
+		name string
+	}{
+		{append([]byte{0x08, 0xc3, 0x79, 0xa0}, reason...), "not allowed", iotex.RevertErrorName},
+		{panicData, hex.EncodeToString(panicData), iotex.RevertPanicName},
+	} {
+		h, err = c.Contract(failContract, failABI).Execute("fail", v.data).SetGasLimit(100000).Call(ctx)
+		require.NoError(err)
+		r = commitAndWait(t, b, h)
+		require.EqualValues(iotextypes.ReceiptStatus_ErrExecutionReverted, r.GetStatus())
+		require.Equal(v.msg, r.GetExecutionRevertMsg())
+		require.Equal(v.name, iotex.ReceiptRevertError(nil, r).Name)
+		// the gas estimation fails as the node does
+		_, err = c.Contract(failContract, failABI).Execute("fail", v.data).Call(ctx)
+		var revertErr *iotex.RevertError
+		require.True(errors.As(err, &revertErr))
+		require.Equal(v.name, revertErr.Name)
+	}
+}
+
+func TestRefundPerAction(t *testing.T) {
+	require := require.New(t)
+	acc, err := account.NewAccount()
+	require.NoError(err)
+	b := newTestBackend(t, acc)
+	c := iotex.NewAuthedClient(b.Client(), _chainID, acc)
+	ctx := context.Background()
+
+	setGetABI, err := abi.JSON(strings.NewReader(_setGetABI))
+	require.NoError(err)
+	data, err := hex.DecodeString(_setGetBin)
+	require.NoError(err)
+	var contracts []address.Address
+	for i := 0; i < 3; i++ {
+		h, err := c.DeployContract(data).SetArgs(setGetABI, big.NewInt(10)).Call(ctx)
+		require.NoError(err)
+		r := commitAndWait(t, b, h)
+		contract, err := address.FromString(r.GetContractAddress())
+		require.NoError(err)
+		contracts = append(contracts, contract)
+	}
+
+	// two executions clearing the storage, and one updating it, in a block
+	var hashes []hash.Hash256
+	for i, x := range []int64{0, 0, 20} {
+		h, err := c.Contract(contracts[i], setGetABI).Execute("set", big.NewInt(x)).SetGasLimit(100000).Call(ctx)
+		require.NoError(err)
+		hashes = append(hashes, h)
+	}
+	var receipts []*iotextypes.Receipt
+	for _, h := range hashes {
+		receipts = append(receipts, commitAndWait(t, b, h))
+	}
+	require.Equal(receipts[0].GetBlkHeight(), receipts[2].GetBlkHeight())
+	require.Equal(receipts[0].GetGasConsumed(), receipts[1].GetGasConsumed())
+
+	// the update costs the same in a block alone, without the refunds of the previous actions
+	h, err := c.Contract(contracts[2], setGetABI).Execute("set", big.NewInt(30)).SetGasLimit(100000).Call(ctx)
+	require.NoError(err)
+	r := commitAndWait(t, b, h)
+	require.Equal(r.GetGasConsumed(), receipts[2].GetGasConsumed())
+}
+
+func TestMalformedContract(t *testing.T) {
+	require := require.New(t)
+	acc, err := account.NewAccount()
+	require.NoError(err)
+	b := newTestBackend(t, acc)
+	c := iotex.NewAuthedClient(b.Client(), _chainID, acc)
+	ctx := context.Background()
+
+	setGetABI, err := abi.JSON(strings.NewReader(_setGetABI))
+	require.NoError(err)
+	u, err := iotex.BuildAction(ctx, c.Contract(acc.Address(), setGetABI).Execute("set", big.NewInt(1)).SetGasLimit(100000))
+	require.NoError(err)
+	u.Core.GetExecution().Contract = "io1malformed"
+	act, err := u.Sign(acc)
+	require.NoError(err)
+	_, err = b.Client().SendAction(ctx, &iotexapi.SendActionRequest{Action: act})
+	require.Equal(codes.InvalidArgument, status.Code(err))
+
+	// the nonce is bumped even if the execution fails before the EVM
+	h, err := iotex.ActionHash(act, iotex.EVMNetworkID(_chainID))
+	require.NoError(err)
+	b.mutex.Lock()
+	b.pending = append(b.pending, &pendingAction{action: act, hash: h, sender: acc.Address()})
+	b.mutex.Unlock()
+	r := commitAndWait(t, b, h)
+	require.EqualValues(iotextypes.ReceiptStatus_Failure, r.GetStatus())
+	res, err := b.Client().GetAccount(ctx, &iotexapi.GetAccountRequest{Address: acc.Address().String()})
+	require.NoError(err)
+	require.Equal(act.GetCore().GetNonce()+1, res.GetAccountMeta().GetNonce())
+	h, err = c.Transfer(acc.Address(), big.NewInt(1)).SetNonce(act.GetCore().GetNonce() + 1).Call(ctx)
+	require.NoError(err)
+	r = commitAndWait(t, b, h)
+	require.EqualValues(iotextypes.ReceiptStatus_Success, r.GetStatus())
+}
+
+func TestStaking(t *testing.T) {
+	require := require.New(t)
+	acc, err := account.NewAccount()
+	require.NoError(err)
+	voter, err := account.NewAccount()
+	require.NoError(err)
+	b := newTestBackend(t, acc, voter)
+	ctx := context.Background()
+	amount := new(big.Int).Mul(big.NewInt(100), big.NewInt(1e18))
+
+	c := iotex.NewAuthedClient(b.Client(), _chainID, acc)
+	h, err := c.Candidate().Register("robotbp00000", acc.Address(), acc.Address(), acc.Address(), amount, 91, true, nil).Call(ctx)
+	require.NoError(err)
+	r := commitAndWait(t, b, h)
+	require.EqualValues(iotextypes.ReceiptStatus_Success, r.GetStatus())
+	h, err = c.Candidate().Register("robotbp00000", voter.Address(), voter.Address(), voter.Address(), amount, 91, true, nil).Call(ctx)
+	require.NoError(err)
+	r = commitAndWait(t, b, h)
+	require.EqualValues(iotextypes.ReceiptStatus_ErrCandidateAlreadyExist, r.GetStatus())
+
+	v := iotex.NewAuthedClient(b.Client(), _chainID, voter, iotex.WithEthereumRLP())
+	h, err = v.Staking().Create("robotbp00000", amount, 7, false).Call(ctx)
+	require.NoError(err)
+	r = commitAndWait(t, b, h)
+	require.EqualValues(iotextypes.ReceiptStatus_Success, r.GetStatus())
+	bucket := b.Bucket(1)
+	require.Equal(voter.Address().String(), bucket.GetOwner())
+	require.Equal(acc.Address().String(), bucket.GetCandidateAddress())
+	require.Equal(new(big.Int).Mul(amount, big.NewInt(2)).String(), b.Candidate("robotbp00000").GetTotalWeightedVotes())
+
+	// only the owner can unstake
+	h, err = c.Staking().Unstake(1).Call(ctx)
+	require.NoError(err)
+	r = commitAndWait(t, b, h)
+	require.EqualValues(iotextypes.ReceiptStatus_ErrUnauthorizedOperator, r.GetStatus())
+	h, err = v.Staking().Withdraw(1).Call(ctx)
+	require.NoError(err)
+	r = commitAndWait(t, b, h)
+	require.EqualValues(iotextypes.ReceiptStatus_ErrWithdrawBeforeUnstake, r.GetStatus())
+	h, err = v.Staking().Unstake(1).Call(ctx)
+	require.NoError(err)
+	r = commitAndWait(t, b, h)
+	require.EqualValues(iotextypes.ReceiptStatus_Success, r.GetStatus())
+	require.Equal(amount.String(), b.Candidate("robotbp00000").GetTotalWeightedVotes())
+	balance := b.Balance(voter.Address())
+	h, err = v.Staking().Withdraw(1).Call(ctx)
+	require.NoError(err)
+	r = commitAndWait(t, b, h)
+	require.EqualValues(iotextypes.ReceiptStatus_Success, r.GetStatus())
+	require.Nil(b.Bucket(1))
+	fee := new(big.Int).Mul(new(big.Int).SetUint64(r.GetGasConsumed()), big.NewInt(1000000000000))
+	require.Equal(balance.Add(balance, amount).Sub(balance, fee), b.Balance(voter.Address()))
+}
+
+func TestStreamBlocks(t *testing.T) {
+	require := require.New(t)
+	acc, err := account.NewAccount()
+	require.NoError(err)
+	b := newTestBackend(t, acc)
+	c := iotex.NewAuthedClient(b.Client(), _chainID, acc)
+	h, err := c.Transfer(acc.Address(), big.NewInt(1)).Call(context.Background())
+	require.NoError(err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	stream, err := b.Client().StreamBlocks(ctx, &iotexapi.StreamBlocksRequest{})
+	require.NoError(err)
+	done := make(chan *iotextypes.Receipt)
+	go func() {
+		r, err := wait.WaitForReceipt(ctx, b.Client(), h, wait.WithStreamBlocks(), wait.WithConfirmations(1))
+		require.NoError(err)
+		done <- r
+	}()
+	for i := 0; i < 2; i++ {
+		// make sure the block is sent after the stream starts
+		time.Sleep(100 * time.Millisecond)
+		b.Commit()
+		res, err := stream.Recv()
+		require.NoError(err)
+		require.EqualValues(i+1, res.GetBlock().GetBlock().GetHeader().GetCore().GetHeight())
+	}
+	r := <-done
+	require.EqualValues(1, r.GetBlkHeight())
+}
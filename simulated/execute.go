@@ -0,0 +1,449 @@
+// Copyright (c) 2020 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package simulated
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/holiman/uint256"
+	"github.com/iotexproject/iotex-address/address"
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// intrinsic gas of the actions, same as the IoTeX protocol
+const (
+	_actionBaseIntrinsicGas = 10000
+	_actionPayloadGas       = 100
+)
+
+var _revertSelector = crypto.Keccak256([]byte("Error(string)"))[:4]
+
+var _evmErrStatus = map[error]iotextypes.ReceiptStatus{
+	vm.ErrOutOfGas:                 iotextypes.ReceiptStatus_ErrOutOfGas,
+	vm.ErrCodeStoreOutOfGas:        iotextypes.ReceiptStatus_ErrCodeStoreOutOfGas,
+	vm.ErrDepth:                    iotextypes.ReceiptStatus_ErrDepth,
+	vm.ErrContractAddressCollision: iotextypes.ReceiptStatus_ErrContractAddressCollision,
+	vm.ErrExecutionReverted:        iotextypes.ReceiptStatus_ErrExecutionReverted,
+	vm.ErrMaxCodeSizeExceeded:      iotextypes.ReceiptStatus_ErrMaxCodeSizeExceeded,
+	vm.ErrWriteProtection:          iotextypes.ReceiptStatus_ErrWriteProtection,
+	vm.ErrInsufficientBalance:      iotextypes.ReceiptStatus_ErrInsufficientBalance,
+	vm.ErrInvalidJump:              iotextypes.ReceiptStatus_ErrInvalidJump,
+	vm.ErrReturnDataOutOfBounds:    iotextypes.ReceiptStatus_ErrReturnDataOutOfBounds,
+	vm.ErrGasUintOverflow:          iotextypes.ReceiptStatus_ErrGasUintOverflow,
+	vm.ErrInvalidCode:              iotextypes.ReceiptStatus_ErrInvalidCode,
+}
+
+// intrinsicGas returns the gas charged before the action is executed
+func intrinsicGas(core *iotextypes.ActionCore) uint64 {
+	var payload []byte
+	switch {
+	case core.GetTransfer() != nil:
+		payload = core.GetTransfer().GetPayload()
+	case core.GetExecution() != nil:
+		payload = core.GetExecution().GetData()
+	case core.GetStakeCreate() != nil:
+		payload = core.GetStakeCreate().GetPayload()
+	case core.GetStakeUnstake() != nil:
+		payload = core.GetStakeUnstake().GetPayload()
+	case core.GetStakeWithdraw() != nil:
+		payload = core.GetStakeWithdraw().GetPayload()
+	case core.GetStakeAddDeposit() != nil:
+		payload = core.GetStakeAddDeposit().GetPayload()
+	case core.GetStakeRestake() != nil:
+		payload = core.GetStakeRestake().GetPayload()
+	case core.GetStakeChangeCandidate() != nil:
+		payload = core.GetStakeChangeCandidate().GetPayload()
+	case core.GetStakeTransferOwnership() != nil:
+		payload = core.GetStakeTransferOwnership().GetPayload()
+	case core.GetCandidateRegister() != nil:
+		payload = core.GetCandidateRegister().GetPayload()
+	}
+	return _actionBaseIntrinsicGas + _actionPayloadGas*uint64(len(payload))
+}
+
+// execute runs the action on the state and returns its receipt
+func (b *Backend) execute(blk *block, index int, p *pendingAction) *iotextypes.Receipt {
+	core := p.action.GetCore()
+	sender := toEthAddress(p.sender)
+	receipt := &iotextypes.Receipt{
+		BlkHeight: blk.height,
+		ActHash:   p.hash[:],
+		TxIndex:   uint32(index),
+	}
+	gasPrice, _ := new(big.Int).SetString(core.GetGasPrice(), 10)
+	intrinsic := intrinsicGas(core)
+	b.state.SetTxContext(common.BytesToHash(p.hash[:]), index)
+	// the fee of the gas limit is charged up front, and the unused gas is refunded
+	fee, overflow := uint256.FromBig(new(big.Int).Mul(gasPrice, new(big.Int).SetUint64(core.GetGasLimit())))
+	if overflow || b.state.GetBalance(sender).Cmp(fee) < 0 {
+		b.state.SetNonce(sender, core.GetNonce()+1)
+		receipt.Status = uint64(iotextypes.ReceiptStatus_ErrNotEnoughBalance)
+		return receipt
+	}
+	b.state.SubBalance(sender, fee)
+
+	gasUsed := intrinsic
+	status := iotextypes.ReceiptStatus_Success
+	switch {
+	case core.GetExecution() != nil:
+		res := b.runExecution(b.state, blk, sender, core.GetNonce(), core.GetExecution(), core.GetGasLimit()-intrinsic, gasPrice)
+		// the nonce is bumped by the EVM, but not if the execution fails before it
+		b.state.SetNonce(sender, core.GetNonce()+1)
+		gasUsed += res.gasUsed
+		status = res.status
+		receipt.ExecutionRevertMsg = res.revertMsg
+		if res.contract != (common.Address{}) {
+			addr, _ := address.FromBytes(res.contract.Bytes())
+			receipt.ContractAddress = addr.String()
+		}
+	case core.GetTransfer() != nil:
+		status = b.transfer(sender, core.GetTransfer())
+		b.state.SetNonce(sender, core.GetNonce()+1)
+	default:
+		status = b.stake(blk, p.sender, core)
+		b.state.SetNonce(sender, core.GetNonce()+1)
+	}
+	// no more than the fee charged
+	refund, _ := uint256.FromBig(new(big.Int).Mul(gasPrice, new(big.Int).SetUint64(core.GetGasLimit()-gasUsed)))
+	b.state.AddBalance(sender, refund)
+
+	receipt.Status = uint64(status)
+	receipt.GasConsumed = gasUsed
+	for _, l := range b.state.GetLogs(common.BytesToHash(p.hash[:]), blk.height, common.BytesToHash(blk.hash[:])) {
+		contract, _ := address.FromBytes(l.Address.Bytes())
+		topics := make([][]byte, len(l.Topics))
+		for i, t := range l.Topics {
+			topics[i] = t.Bytes()
+		}
+		receipt.Logs = append(receipt.Logs, &iotextypes.Log{
+			ContractAddress: contract.String(),
+			Topics:          topics,
+			Data:            l.Data,
+			BlkHeight:       blk.height,
+			ActHash:         p.hash[:],
+			Index:           uint32(l.Index),
+			BlkHash:         blk.hash[:],
+			TxIndex:         uint32(index),
+		})
+	}
+	return receipt
+}
+
+type executionResult struct {
+	ret []byte
+	// gasSpent is the gas spent by the EVM, and gasUsed is gasSpent minus the refund
+	gasSpent  uint64
+	gasUsed   uint64
+	status    iotextypes.ReceiptStatus
+	revertMsg string
+	contract  common.Address
+}
+
+// runExecution runs the execution in the EVM, with the state nonce of the sender set to nonce. gas is the gas
+// available to the EVM.
+func (b *Backend) runExecution(st *state.StateDB, blk *block, sender common.Address, nonce uint64, exec *iotextypes.Execution, gas uint64, gasPrice *big.Int) *executionResult {
+	value, err := parseAmount(exec.GetAmount())
+	if err != nil {
+		return &executionResult{status: iotextypes.ReceiptStatus_Failure}
+	}
+	var to *common.Address
+	if exec.GetContract() != "" {
+		addr, err := address.FromString(exec.GetContract())
+		if err != nil {
+			return &executionResult{status: iotextypes.ReceiptStatus_Failure}
+		}
+		ethAddr := toEthAddress(addr)
+		to = &ethAddr
+	}
+
+	random := common.Hash(blk.hash)
+	blockCtx := vm.BlockContext{
+		CanTransfer: func(db vm.StateDB, addr common.Address, amount *uint256.Int) bool {
+			return db.GetBalance(addr).Cmp(amount) >= 0
+		},
+		Transfer: func(db vm.StateDB, from, to common.Address, amount *uint256.Int) {
+			db.SubBalance(from, amount)
+			db.AddBalance(to, amount)
+		},
+		GetHash:     b.blockHash,
+		GasLimit:    b.gasLimit,
+		BlockNumber: new(big.Int).SetUint64(blk.height),
+		Time:        uint64(blk.timestamp.Unix()),
+		Difficulty:  new(big.Int),
+		BaseFee:     new(big.Int),
+		Random:      &random,
+	}
+	evm := vm.NewEVM(blockCtx, vm.TxContext{Origin: sender, GasPrice: gasPrice}, st, b.evmConfig, vm.Config{})
+	rules := b.evmConfig.Rules(blockCtx.BlockNumber, true, blockCtx.Time)
+	st.Prepare(rules, sender, common.Address{}, to, vm.ActivePrecompiles(rules), nil)
+	st.SetNonce(sender, nonce)
+
+	res := &executionResult{}
+	var leftOver uint64
+	if to == nil {
+		res.ret, res.contract, leftOver, err = evm.Create(vm.AccountRef(sender), exec.GetData(), gas, value)
+	} else {
+		st.SetNonce(sender, nonce+1)
+		res.ret, leftOver, err = evm.Call(vm.AccountRef(sender), *to, exec.GetData(), gas, value)
+	}
+	res.gasSpent = gas - leftOver
+	res.gasUsed = res.gasSpent
+	refund := st.GetRefund()
+	if refund > res.gasUsed/5 {
+		refund = res.gasUsed / 5
+	}
+	res.gasUsed -= refund
+	res.status = iotextypes.ReceiptStatus_Success
+	if err != nil {
+		res.contract = common.Address{}
+		res.status = iotextypes.ReceiptStatus_ErrUnknown
+		for e, s := range _evmErrStatus {
+			if errors.Is(err, e) {
+				res.status = s
+				break
+			}
+		}
+		if len(res.ret) > 0 {
+			res.revertMsg = revertMessage(res.ret)
+		}
+	}
+	return res
+}
+
+// revertMessage returns the revert message as the node writes into the receipt, the reason string of Error(string),
+// or the revert data in hex without 0x prefix
+func revertMessage(ret []byte) string {
+	if len(ret) >= 4 && bytes.Equal(ret[:4], _revertSelector) {
+		if reason, err := abi.UnpackRevert(ret); err == nil {
+			return reason
+		}
+	}
+	return hex.EncodeToString(ret)
+}
+
+func (b *Backend) transfer(sender common.Address, tsf *iotextypes.Transfer) iotextypes.ReceiptStatus {
+	value, err := parseAmount(tsf.GetAmount())
+	if err != nil {
+		return iotextypes.ReceiptStatus_Failure
+	}
+	recipient, err := address.FromString(tsf.GetRecipient())
+	if err != nil {
+		return iotextypes.ReceiptStatus_Failure
+	}
+	if b.state.GetBalance(sender).Cmp(value) < 0 {
+		return iotextypes.ReceiptStatus_ErrNotEnoughBalance
+	}
+	b.state.SubBalance(sender, value)
+	b.state.AddBalance(toEthAddress(recipient), value)
+	return iotextypes.ReceiptStatus_Success
+}
+
+// stake runs the staking actions with simplified rules
+func (b *Backend) stake(blk *block, sender address.Address, core *iotextypes.ActionCore) iotextypes.ReceiptStatus {
+	now := timestamppb.New(blk.timestamp)
+	owned := func(index uint64) (*iotextypes.VoteBucket, iotextypes.ReceiptStatus) {
+		if index >= uint64(len(b.buckets)) || b.buckets[index] == nil {
+			return nil, iotextypes.ReceiptStatus_ErrInvalidBucketIndex
+		}
+		if b.buckets[index].GetOwner() != sender.String() {
+			return nil, iotextypes.ReceiptStatus_ErrUnauthorizedOperator
+		}
+		return b.buckets[index], iotextypes.ReceiptStatus_Success
+	}
+
+	switch {
+	case core.GetStakeCreate() != nil:
+		a := core.GetStakeCreate()
+		cand, ok := b.candidates[a.GetCandidateName()]
+		if !ok {
+			return iotextypes.ReceiptStatus_ErrCandidateNotExist
+		}
+		_, status := b.createBucket(sender, cand.GetOwnerAddress(), a.GetStakedAmount(), a.GetStakedDuration(), a.GetAutoStake(), now)
+		return status
+	case core.GetStakeUnstake() != nil:
+		bucket, status := owned(core.GetStakeUnstake().GetBucketIndex())
+		if bucket != nil {
+			bucket.UnstakeStartTime = now
+		}
+		return status
+	case core.GetStakeWithdraw() != nil:
+		index := core.GetStakeWithdraw().GetBucketIndex()
+		bucket, status := owned(index)
+		if bucket == nil {
+			return status
+		}
+		if bucket.GetUnstakeStartTime() == nil {
+			return iotextypes.ReceiptStatus_ErrWithdrawBeforeUnstake
+		}
+		amount, err := parseAmount(bucket.GetStakedAmount())
+		if err != nil {
+			return iotextypes.ReceiptStatus_Failure
+		}
+		b.state.AddBalance(toEthAddress(sender), amount)
+		b.buckets[index] = nil
+		return status
+	case core.GetStakeAddDeposit() != nil:
+		a := core.GetStakeAddDeposit()
+		bucket, status := owned(a.GetBucketIndex())
+		if bucket == nil {
+			return status
+		}
+		if !bucket.GetAutoStake() {
+			return iotextypes.ReceiptStatus_ErrInvalidBucketType
+		}
+		amount, status := b.debit(sender, a.GetAmount())
+		if amount == nil {
+			return status
+		}
+		staked, _ := new(big.Int).SetString(bucket.GetStakedAmount(), 10)
+		bucket.StakedAmount = staked.Add(staked, amount).String()
+		return status
+	case core.GetStakeRestake() != nil:
+		a := core.GetStakeRestake()
+		bucket, status := owned(a.GetBucketIndex())
+		if bucket != nil {
+			bucket.StakedDuration = a.GetStakedDuration()
+			bucket.AutoStake = a.GetAutoStake()
+			bucket.StakeStartTime = now
+		}
+		return status
+	case core.GetStakeChangeCandidate() != nil:
+		a := core.GetStakeChangeCandidate()
+		bucket, status := owned(a.GetBucketIndex())
+		if bucket == nil {
+			return status
+		}
+		cand, ok := b.candidates[a.GetCandidateName()]
+		if !ok {
+			return iotextypes.ReceiptStatus_ErrCandidateNotExist
+		}
+		bucket.CandidateAddress = cand.GetOwnerAddress()
+		return status
+	case core.GetStakeTransferOwnership() != nil:
+		a := core.GetStakeTransferOwnership()
+		bucket, status := owned(a.GetBucketIndex())
+		if bucket != nil {
+			bucket.Owner = a.GetVoterAddress()
+		}
+		return status
+	case core.GetCandidateRegister() != nil:
+		a := core.GetCandidateRegister()
+		name := a.GetCandidate().GetName()
+		owner := a.GetOwnerAddress()
+		if owner == "" {
+			owner = sender.String()
+		}
+		if _, ok := b.candidates[name]; ok {
+			return iotextypes.ReceiptStatus_ErrCandidateAlreadyExist
+		}
+		for _, c := range b.candidates {
+			if c.GetOwnerAddress() == owner || c.GetOperatorAddress() == a.GetCandidate().GetOperatorAddress() {
+				return iotextypes.ReceiptStatus_ErrCandidateConflict
+			}
+		}
+		index, status := b.createBucket(sender, owner, a.GetStakedAmount(), a.GetStakedDuration(), a.GetAutoStake(), now)
+		if status != iotextypes.ReceiptStatus_Success {
+			return status
+		}
+		b.candidates[name] = &iotextypes.CandidateV2{
+			OwnerAddress:       owner,
+			OperatorAddress:    a.GetCandidate().GetOperatorAddress(),
+			RewardAddress:      a.GetCandidate().GetRewardAddress(),
+			Name:               name,
+			SelfStakeBucketIdx: index,
+			SelfStakingTokens:  a.GetStakedAmount(),
+		}
+		return status
+	case core.GetCandidateUpdate() != nil:
+		a := core.GetCandidateUpdate()
+		cand, ok := b.candidates[a.GetName()]
+		if !ok {
+			return iotextypes.ReceiptStatus_ErrCandidateNotExist
+		}
+		if cand.GetOwnerAddress() != sender.String() {
+			return iotextypes.ReceiptStatus_ErrUnauthorizedOperator
+		}
+		if a.GetOperatorAddress() != "" {
+			cand.OperatorAddress = a.GetOperatorAddress()
+		}
+		if a.GetRewardAddress() != "" {
+			cand.RewardAddress = a.GetRewardAddress()
+		}
+		return iotextypes.ReceiptStatus_Success
+	default:
+		return iotextypes.ReceiptStatus_Failure
+	}
+}
+
+func (b *Backend) createBucket(sender address.Address, candidate, amount string, duration uint32, autoStake bool, now *timestamppb.Timestamp) (uint64, iotextypes.ReceiptStatus) {
+	staked, status := b.debit(sender, amount)
+	if staked == nil {
+		return 0, status
+	}
+	index := uint64(len(b.buckets))
+	b.buckets = append(b.buckets, &iotextypes.VoteBucket{
+		Index:            index,
+		CandidateAddress: candidate,
+		StakedAmount:     staked.String(),
+		StakedDuration:   duration,
+		CreateTime:       now,
+		StakeStartTime:   now,
+		AutoStake:        autoStake,
+		Owner:            sender.String(),
+	})
+	return index, status
+}
+
+// debit takes the amount from the balance of the address
+func (b *Backend) debit(addr address.Address, amount string) (*big.Int, iotextypes.ReceiptStatus) {
+	value, err := parseAmount(amount)
+	if err != nil || value.IsZero() {
+		return nil, iotextypes.ReceiptStatus_ErrInvalidBucketAmount
+	}
+	if b.state.GetBalance(toEthAddress(addr)).Cmp(value) < 0 {
+		return nil, iotextypes.ReceiptStatus_ErrNotEnoughBalance
+	}
+	b.state.SubBalance(toEthAddress(addr), value)
+	return value.ToBig(), iotextypes.ReceiptStatus_Success
+}
+
+// parseAmount parses the decimal amount in the range of uint256, and the empty amount is 0
+func parseAmount(s string) (*uint256.Int, error) {
+	if s == "" {
+		return new(uint256.Int), nil
+	}
+	v, ok := new(big.Int).SetString(s, 10)
+	if !ok || v.Sign() < 0 {
+		return nil, fmt.Errorf("invalid amount %s", s)
+	}
+	value, overflow := uint256.FromBig(v)
+	if overflow {
+		return nil, fmt.Errorf("amount %s exceeds 256 bits", s)
+	}
+	return value, nil
+}
+
+func (b *Backend) blockHash(n uint64) common.Hash {
+	if n >= uint64(len(b.blocks)) {
+		return common.Hash{}
+	}
+	return common.Hash(b.blocks[n].hash)
+}
+
+func toEthAddress(addr address.Address) common.Address {
+	return common.BytesToAddress(addr.Bytes())
+}
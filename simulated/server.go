@@ -0,0 +1,543 @@
+// Copyright (c) 2020 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package simulated
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"math/big"
+
+	"github.com/holiman/uint256"
+	"github.com/iotexproject/go-pkgs/crypto"
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/iotexproject/iotex-address/address"
+	"github.com/iotexproject/iotex-proto/golang/iotexapi"
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/iotexproject/iotex-antenna-go/v2/iotex"
+)
+
+// server serves the API of the Backend. The methods not listed here return codes.Unimplemented.
+type server struct {
+	iotexapi.UnimplementedAPIServiceServer
+	b *Backend
+}
+
+func (s *server) GetAccount(_ context.Context, in *iotexapi.GetAccountRequest) (*iotexapi.GetAccountResponse, error) {
+	addr, err := address.FromString(in.GetAddress())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	b := s.b
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	ethAddr := toEthAddress(addr)
+	nonce := b.state.GetNonce(ethAddr)
+	return &iotexapi.GetAccountResponse{
+		AccountMeta: &iotextypes.AccountMeta{
+			Address:      addr.String(),
+			Balance:      b.state.GetBalance(ethAddr).ToBig().String(),
+			Nonce:        nonce,
+			PendingNonce: nonce + b.pendingCount(addr),
+			IsContract:   b.state.GetCodeSize(ethAddr) > 0,
+		},
+		BlockIdentifier: &iotextypes.BlockIdentifier{
+			Hash:   hex.EncodeToString(b.tip().hash[:]),
+			Height: b.tip().height,
+		},
+	}, nil
+}
+
+func (s *server) GetActions(_ context.Context, in *iotexapi.GetActionsRequest) (*iotexapi.GetActionsResponse, error) {
+	if in.GetByHash() == nil {
+		return nil, status.Error(codes.Unimplemented, "only lookup by hash is supported")
+	}
+	h, err := hash.HexStringToHash256(in.GetByHash().GetActionHash())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	b := s.b
+	b.mutex.RLock()
+	defer b.mutex.RUnlock()
+	info, ok := b.actions[h]
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "action %x is not found", h)
+	}
+	return &iotexapi.GetActionsResponse{
+		Total: 1,
+		ActionInfo: []*iotexapi.ActionInfo{{
+			Action:    info.action,
+			ActHash:   hex.EncodeToString(h[:]),
+			BlkHash:   hex.EncodeToString(info.block.hash[:]),
+			Timestamp: timestamppb.New(info.block.timestamp),
+			BlkHeight: info.block.height,
+			Sender:    info.sender.String(),
+			GasFee:    gasFee(info.action.GetCore(), info.receipt).String(),
+			Index:     uint32(info.index),
+		}},
+	}, nil
+}
+
+func (s *server) GetBlockMetas(_ context.Context, in *iotexapi.GetBlockMetasRequest) (*iotexapi.GetBlockMetasResponse, error) {
+	b := s.b
+	b.mutex.RLock()
+	defer b.mutex.RUnlock()
+	var blks []*block
+	switch {
+	case in.GetByIndex() != nil:
+		start, count := in.GetByIndex().GetStart(), in.GetByIndex().GetCount()
+		if start == 0 || count == 0 {
+			return nil, status.Error(codes.InvalidArgument, "start and count must be greater than 0")
+		}
+		for i := start; i < start+count && i < uint64(len(b.blocks)); i++ {
+			blks = append(blks, b.blocks[i])
+		}
+	case in.GetByHash() != nil:
+		h, err := hash.HexStringToHash256(in.GetByHash().GetBlkHash())
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		blk := b.blockByHash(h)
+		if blk == nil {
+			return nil, status.Errorf(codes.NotFound, "block %x is not found", h)
+		}
+		blks = append(blks, blk)
+	default:
+		return nil, status.Error(codes.InvalidArgument, "invalid lookup")
+	}
+	res := &iotexapi.GetBlockMetasResponse{Total: uint64(len(blks))}
+	for _, blk := range blks {
+		var gasUsed uint64
+		for _, r := range blk.receipts {
+			gasUsed += r.GetGasConsumed()
+		}
+		res.BlkMetas = append(res.BlkMetas, &iotextypes.BlockMeta{
+			Hash:              hex.EncodeToString(blk.hash[:]),
+			Height:            blk.height,
+			Timestamp:         timestamppb.New(blk.timestamp),
+			NumActions:        int64(len(blk.actions)),
+			PreviousBlockHash: hex.EncodeToString(b.blocks[blk.height-1].hash[:]),
+			GasLimit:          b.gasLimit,
+			GasUsed:           gasUsed,
+		})
+	}
+	return res, nil
+}
+
+func (s *server) GetChainMeta(context.Context, *iotexapi.GetChainMetaRequest) (*iotexapi.GetChainMetaResponse, error) {
+	b := s.b
+	b.mutex.RLock()
+	defer b.mutex.RUnlock()
+	return &iotexapi.GetChainMetaResponse{
+		ChainMeta: &iotextypes.ChainMeta{
+			Height:     b.tip().height,
+			NumActions: int64(len(b.actions)),
+			ChainID:    b.chainID,
+		},
+	}, nil
+}
+
+func (s *server) SendAction(_ context.Context, in *iotexapi.SendActionRequest) (*iotexapi.SendActionResponse, error) {
+	b := s.b
+	act := in.GetAction()
+	core := act.GetCore()
+	if core.GetChainID() != b.chainID {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid chain ID %d", core.GetChainID())
+	}
+	evmID := iotex.EVMNetworkID(b.chainID)
+	if err := iotex.VerifyAction(act, evmID); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	h, err := iotex.ActionHash(act, evmID)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	pk, err := crypto.BytesToPublicKey(act.GetSenderPubKey())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	sender := pk.Address()
+	gasPrice, ok := new(big.Int).SetString(core.GetGasPrice(), 10)
+	if !ok || gasPrice.Sign() < 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid gas price %s", core.GetGasPrice())
+	}
+	if core.GetGasLimit() < intrinsicGas(core) {
+		return nil, status.Errorf(codes.InvalidArgument, "intrinsic gas too low: have %d, want %d", core.GetGasLimit(), intrinsicGas(core))
+	}
+	if core.GetGasLimit() > b.gasLimit {
+		return nil, status.Errorf(codes.InvalidArgument, "gas limit %d exceeds block gas limit %d", core.GetGasLimit(), b.gasLimit)
+	}
+	if core.GetGrantReward() != nil || core.GetClaimFromRewardingFund() != nil || core.GetDepositToRewardingFund() != nil {
+		return nil, status.Error(codes.Unimplemented, "rewarding actions are not supported")
+	}
+	if err := checkAction(core); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	if _, ok := b.actions[h]; ok {
+		return nil, status.Errorf(codes.AlreadyExists, "action %x already exists", h)
+	}
+	for _, p := range b.pending {
+		if p.hash == h {
+			return nil, status.Errorf(codes.AlreadyExists, "action %x already exists", h)
+		}
+	}
+	ethAddr := toEthAddress(sender)
+	switch nonce := b.state.GetNonce(ethAddr) + b.pendingCount(sender); {
+	case core.GetNonce() < nonce:
+		return nil, status.Errorf(codes.InvalidArgument, "nonce too low: have %d, want %d", core.GetNonce(), nonce)
+	case core.GetNonce() > nonce:
+		return nil, status.Errorf(codes.InvalidArgument, "nonce too high: have %d, want %d", core.GetNonce(), nonce)
+	}
+	fee, overflow := uint256.FromBig(new(big.Int).Mul(gasPrice, new(big.Int).SetUint64(core.GetGasLimit())))
+	if overflow {
+		return nil, status.Errorf(codes.InvalidArgument, "gas fee of %s exceeds 256 bits", sender.String())
+	}
+	if b.state.GetBalance(ethAddr).Cmp(fee) < 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "insufficient balance of %s for gas", sender.String())
+	}
+	b.pending = append(b.pending, &pendingAction{action: act, hash: h, sender: sender})
+	return &iotexapi.SendActionResponse{ActionHash: hex.EncodeToString(h[:])}, nil
+}
+
+func (s *server) GetReceiptByAction(_ context.Context, in *iotexapi.GetReceiptByActionRequest) (*iotexapi.GetReceiptByActionResponse, error) {
+	h, err := hash.HexStringToHash256(in.GetActionHash())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	b := s.b
+	b.mutex.RLock()
+	defer b.mutex.RUnlock()
+	info, ok := b.actions[h]
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "receipt of action %x is not found", h)
+	}
+	return &iotexapi.GetReceiptByActionResponse{
+		ReceiptInfo: &iotexapi.ReceiptInfo{
+			Receipt: info.receipt,
+			BlkHash: hex.EncodeToString(info.block.hash[:]),
+		},
+	}, nil
+}
+
+func (s *server) ReadContract(_ context.Context, in *iotexapi.ReadContractRequest) (*iotexapi.ReadContractResponse, error) {
+	caller := address.ZeroAddress
+	if in.GetCallerAddress() != "" {
+		caller = in.GetCallerAddress()
+	}
+	sender, err := address.FromString(caller)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	gasPrice := new(big.Int)
+	if in.GetGasPrice() != "" {
+		if _, ok := gasPrice.SetString(in.GetGasPrice(), 10); !ok {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid gas price %s", in.GetGasPrice())
+		}
+	}
+	if _, err := parseAmount(in.GetExecution().GetAmount()); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	gasLimit := in.GetGasLimit()
+	if gasLimit == 0 {
+		gasLimit = s.b.gasLimit
+	}
+	res := s.b.simulate(sender, in.GetExecution(), gasLimit, gasPrice)
+	return &iotexapi.ReadContractResponse{
+		Data: hex.EncodeToString(res.ret),
+		Receipt: &iotextypes.Receipt{
+			Status:             uint64(res.status),
+			GasConsumed:        res.gasUsed,
+			ExecutionRevertMsg: res.revertMsg,
+		},
+	}, nil
+}
+
+func (s *server) SuggestGasPrice(context.Context, *iotexapi.SuggestGasPriceRequest) (*iotexapi.SuggestGasPriceResponse, error) {
+	return &iotexapi.SuggestGasPriceResponse{GasPrice: s.b.gasPrice.Uint64()}, nil
+}
+
+func (s *server) EstimateGasForAction(_ context.Context, in *iotexapi.EstimateGasForActionRequest) (*iotexapi.EstimateGasForActionResponse, error) {
+	act := in.GetAction()
+	pk, err := crypto.BytesToPublicKey(act.GetSenderPubKey())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	gas, err := s.b.estimateGas(pk.Address(), act.GetCore())
+	if err != nil {
+		return nil, err
+	}
+	return &iotexapi.EstimateGasForActionResponse{Gas: gas}, nil
+}
+
+func (s *server) EstimateActionGasConsumption(_ context.Context, in *iotexapi.EstimateActionGasConsumptionRequest) (*iotexapi.EstimateActionGasConsumptionResponse, error) {
+	caller := address.ZeroAddress
+	if in.GetCallerAddress() != "" {
+		caller = in.GetCallerAddress()
+	}
+	sender, err := address.FromString(caller)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	core := &iotextypes.ActionCore{}
+	switch a := in.GetAction().(type) {
+	case *iotexapi.EstimateActionGasConsumptionRequest_Transfer:
+		core.Action = &iotextypes.ActionCore_Transfer{Transfer: a.Transfer}
+	case *iotexapi.EstimateActionGasConsumptionRequest_Execution:
+		core.Action = &iotextypes.ActionCore_Execution{Execution: a.Execution}
+	case *iotexapi.EstimateActionGasConsumptionRequest_StakeCreate:
+		core.Action = &iotextypes.ActionCore_StakeCreate{StakeCreate: a.StakeCreate}
+	case *iotexapi.EstimateActionGasConsumptionRequest_StakeUnstake:
+		core.Action = &iotextypes.ActionCore_StakeUnstake{StakeUnstake: a.StakeUnstake}
+	case *iotexapi.EstimateActionGasConsumptionRequest_StakeWithdraw:
+		core.Action = &iotextypes.ActionCore_StakeWithdraw{StakeWithdraw: a.StakeWithdraw}
+	case *iotexapi.EstimateActionGasConsumptionRequest_StakeAddDeposit:
+		core.Action = &iotextypes.ActionCore_StakeAddDeposit{StakeAddDeposit: a.StakeAddDeposit}
+	case *iotexapi.EstimateActionGasConsumptionRequest_StakeRestake:
+		core.Action = &iotextypes.ActionCore_StakeRestake{StakeRestake: a.StakeRestake}
+	case *iotexapi.EstimateActionGasConsumptionRequest_StakeChangeCandidate:
+		core.Action = &iotextypes.ActionCore_StakeChangeCandidate{StakeChangeCandidate: a.StakeChangeCandidate}
+	case *iotexapi.EstimateActionGasConsumptionRequest_StakeTransferOwnership:
+		core.Action = &iotextypes.ActionCore_StakeTransferOwnership{StakeTransferOwnership: a.StakeTransferOwnership}
+	case *iotexapi.EstimateActionGasConsumptionRequest_CandidateRegister:
+		core.Action = &iotextypes.ActionCore_CandidateRegister{CandidateRegister: a.CandidateRegister}
+	case *iotexapi.EstimateActionGasConsumptionRequest_CandidateUpdate:
+		core.Action = &iotextypes.ActionCore_CandidateUpdate{CandidateUpdate: a.CandidateUpdate}
+	default:
+		return nil, status.Error(codes.InvalidArgument, "invalid action")
+	}
+	gas, err := s.b.estimateGas(sender, core)
+	if err != nil {
+		return nil, err
+	}
+	return &iotexapi.EstimateActionGasConsumptionResponse{Gas: gas}, nil
+}
+
+func (s *server) GetLogs(_ context.Context, in *iotexapi.GetLogsRequest) (*iotexapi.GetLogsResponse, error) {
+	b := s.b
+	b.mutex.RLock()
+	defer b.mutex.RUnlock()
+	var blks []*block
+	switch {
+	case in.GetByBlock() != nil:
+		blk := b.blockByHash(hash.BytesToHash256(in.GetByBlock().GetBlockHash()))
+		if blk == nil {
+			return nil, status.Errorf(codes.NotFound, "block %x is not found", in.GetByBlock().GetBlockHash())
+		}
+		blks = append(blks, blk)
+	case in.GetByRange() != nil:
+		from, to := in.GetByRange().GetFromBlock(), in.GetByRange().GetToBlock()
+		if from > to {
+			return nil, status.Error(codes.InvalidArgument, "start block > end block")
+		}
+		for i := from; i <= to && i < uint64(len(b.blocks)); i++ {
+			blks = append(blks, b.blocks[i])
+		}
+	default:
+		return nil, status.Error(codes.InvalidArgument, "invalid GetLogsRequest type")
+	}
+	res := &iotexapi.GetLogsResponse{}
+	for _, blk := range blks {
+		res.Logs = append(res.Logs, filterLogs(in.GetFilter(), blk)...)
+	}
+	return res, nil
+}
+
+func (s *server) StreamBlocks(_ *iotexapi.StreamBlocksRequest, stream iotexapi.APIService_StreamBlocksServer) error {
+	b := s.b
+	sub := b.subscribe()
+	defer b.unsubscribe(sub)
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case blk, ok := <-sub:
+			if !ok {
+				return status.Error(codes.ResourceExhausted, "subscriber is too slow")
+			}
+			if err := stream.Send(&iotexapi.StreamBlocksResponse{Block: b.blockInfo(blk)}); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func (s *server) StreamLogs(in *iotexapi.StreamLogsRequest, stream iotexapi.APIService_StreamLogsServer) error {
+	b := s.b
+	sub := b.subscribe()
+	defer b.unsubscribe(sub)
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case blk, ok := <-sub:
+			if !ok {
+				return status.Error(codes.ResourceExhausted, "subscriber is too slow")
+			}
+			for _, l := range filterLogs(in.GetFilter(), blk) {
+				if err := stream.Send(&iotexapi.StreamLogsResponse{Log: l}); err != nil {
+					return err
+				}
+			}
+		}
+	}
+}
+
+// pendingCount returns the number of pending actions of the sender
+func (b *Backend) pendingCount(sender address.Address) uint64 {
+	var n uint64
+	for _, p := range b.pending {
+		if p.sender.String() == sender.String() {
+			n++
+		}
+	}
+	return n
+}
+
+func (b *Backend) blockByHash(h hash.Hash256) *block {
+	for _, blk := range b.blocks[1:] {
+		if blk.hash == h {
+			return blk
+		}
+	}
+	return nil
+}
+
+func (b *Backend) blockInfo(blk *block) *iotexapi.BlockInfo {
+	b.mutex.RLock()
+	prev := b.blocks[blk.height-1].hash
+	b.mutex.RUnlock()
+	return &iotexapi.BlockInfo{
+		Block: &iotextypes.Block{
+			Header: &iotextypes.BlockHeader{
+				Core: &iotextypes.BlockHeaderCore{
+					Version:       1,
+					Height:        blk.height,
+					Timestamp:     timestamppb.New(blk.timestamp),
+					PrevBlockHash: prev[:],
+				},
+			},
+			Body: &iotextypes.BlockBody{Actions: blk.actions},
+		},
+		Receipts: blk.receipts,
+	}
+}
+
+// simulate runs the execution on a copy of the state in a block on top of the tip
+func (b *Backend) simulate(sender address.Address, exec *iotextypes.Execution, gas uint64, gasPrice *big.Int) *executionResult {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	st := b.state.Copy()
+	tip := b.tip()
+	blk := &block{
+		height:    tip.height + 1,
+		timestamp: tip.timestamp.Add(b.blockInterval),
+	}
+	ethAddr := toEthAddress(sender)
+	return b.runExecution(st, blk, ethAddr, st.GetNonce(ethAddr), exec, gas, gasPrice)
+}
+
+// estimateGas returns the intrinsic gas of the action, plus the gas spent by the EVM for executions
+func (b *Backend) estimateGas(sender address.Address, core *iotextypes.ActionCore) (uint64, error) {
+	intrinsic := intrinsicGas(core)
+	if core.GetExecution() == nil {
+		return intrinsic, nil
+	}
+	if intrinsic > b.gasLimit {
+		return 0, status.Error(codes.InvalidArgument, "intrinsic gas exceeds block gas limit")
+	}
+	if err := checkAction(core); err != nil {
+		return 0, status.Error(codes.InvalidArgument, err.Error())
+	}
+	res := b.simulate(sender, core.GetExecution(), b.gasLimit-intrinsic, new(big.Int))
+	// the errors of the node
+	switch {
+	case res.status == iotextypes.ReceiptStatus_Success:
+	case res.revertMsg != "":
+		return 0, status.Error(codes.Internal, "execution simulation is reverted due to the reason: "+res.revertMsg)
+	default:
+		return 0, status.Errorf(codes.Internal, "execution simulation failed: status = %d", res.status)
+	}
+	return intrinsic + res.gasSpent, nil
+}
+
+// checkAction checks the amount of the transfer or the execution is in the range of uint256, and the contract of the
+// execution is an address, as the node rejects such actions before accepting them
+func checkAction(core *iotextypes.ActionCore) error {
+	var err error
+	switch {
+	case core.GetTransfer() != nil:
+		_, err = parseAmount(core.GetTransfer().GetAmount())
+	case core.GetExecution() != nil:
+		if _, err = parseAmount(core.GetExecution().GetAmount()); err == nil && core.GetExecution().GetContract() != "" {
+			_, err = address.FromString(core.GetExecution().GetContract())
+		}
+	}
+	return err
+}
+
+func gasFee(core *iotextypes.ActionCore, r *iotextypes.Receipt) *big.Int {
+	gasPrice, ok := new(big.Int).SetString(core.GetGasPrice(), 10)
+	if !ok {
+		return new(big.Int)
+	}
+	return gasPrice.Mul(gasPrice, new(big.Int).SetUint64(r.GetGasConsumed()))
+}
+
+// filterLogs returns the logs of the block matching the filter
+func filterLogs(filter *iotexapi.LogsFilter, blk *block) []*iotextypes.Log {
+	var logs []*iotextypes.Log
+	for _, r := range blk.receipts {
+		for _, l := range r.GetLogs() {
+			if matchLog(filter, l) {
+				logs = append(logs, l)
+			}
+		}
+	}
+	return logs
+}
+
+func matchLog(filter *iotexapi.LogsFilter, l *iotextypes.Log) bool {
+	if len(filter.GetAddress()) > 0 {
+		found := false
+		for _, addr := range filter.GetAddress() {
+			if addr == l.GetContractAddress() {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	if len(filter.GetTopics()) > len(l.GetTopics()) {
+		return false
+	}
+	for i, topics := range filter.GetTopics() {
+		if len(topics.GetTopic()) == 0 {
+			// wildcard
+			continue
+		}
+		found := false
+		for _, topic := range topics.GetTopic() {
+			if bytes.Equal(topic, l.GetTopics()[i]) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,241 @@
+// Copyright (c) 2020 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+// Package siwe implements Sign-In with IoTeX, the login flow of EIP-4361 Sign-In with Ethereum for IoTeX accounts.
+package siwe
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/iotexproject/iotex-address/address"
+	"github.com/pkg/errors"
+)
+
+const (
+	// Version is the version of the message
+	Version = "1"
+
+	_header    = " wants you to sign in with your IoTeX account:"
+	_ethHeader = " wants you to sign in with your Ethereum account:"
+
+	_uriTag            = "URI: "
+	_versionTag        = "Version: "
+	_chainIDTag        = "Chain ID: "
+	_nonceTag          = "Nonce: "
+	_issuedAtTag       = "Issued At: "
+	_expirationTimeTag = "Expiration Time: "
+	_notBeforeTag      = "Not Before: "
+	_requestIDTag      = "Request ID: "
+	_resourcesTag      = "Resources:"
+)
+
+// ErrInvalidMessage is returned when the message is not in the format of EIP-4361
+var ErrInvalidMessage = errors.New("invalid sign-in message")
+
+// Message is the sign-in message
+type Message struct {
+	// Domain is the domain requesting the sign-in, such as example.com
+	Domain string
+	// Address is the address signing in
+	Address address.Address
+	// EthAddress writes the address in the 0x form, for the wallets of Ethereum
+	EthAddress bool
+	// Statement is the optional statement to the user, in one line
+	Statement string
+	// URI is the URI of the resource that is the subject of the signing
+	URI string
+	// Version is the version of the message, which must be Version
+	Version string
+	// ChainID is the EVM network ID of the chain, such as 4689 for mainnet
+	ChainID uint32
+	// Nonce is the random nonce against replay attacks, issued by the server
+	Nonce string
+	// IssuedAt is the time the message is issued
+	IssuedAt time.Time
+	// ExpirationTime is the optional time the message expires
+	ExpirationTime time.Time
+	// NotBefore is the optional time the message becomes valid
+	NotBefore time.Time
+	// RequestID is the optional ID of the request
+	RequestID string
+	// Resources is the optional URIs of the resources to access
+	Resources []string
+}
+
+// String returns the message to sign, in the format of EIP-4361
+func (m *Message) String() string {
+	var b strings.Builder
+	b.WriteString(m.Domain + _header + "\n")
+	if m.EthAddress {
+		b.WriteString(common.BytesToAddress(m.Address.Bytes()).Hex() + "\n")
+	} else {
+		b.WriteString(m.Address.String() + "\n")
+	}
+	b.WriteString("\n")
+	if m.Statement != "" {
+		b.WriteString(m.Statement + "\n")
+	}
+	b.WriteString("\n")
+	b.WriteString(_uriTag + m.URI + "\n")
+	b.WriteString(_versionTag + m.Version + "\n")
+	b.WriteString(_chainIDTag + strconv.FormatUint(uint64(m.ChainID), 10) + "\n")
+	b.WriteString(_nonceTag + m.Nonce + "\n")
+	b.WriteString(_issuedAtTag + m.IssuedAt.UTC().Format(time.RFC3339))
+	if !m.ExpirationTime.IsZero() {
+		b.WriteString("\n" + _expirationTimeTag + m.ExpirationTime.UTC().Format(time.RFC3339))
+	}
+	if !m.NotBefore.IsZero() {
+		b.WriteString("\n" + _notBeforeTag + m.NotBefore.UTC().Format(time.RFC3339))
+	}
+	if m.RequestID != "" {
+		b.WriteString("\n" + _requestIDTag + m.RequestID)
+	}
+	if len(m.Resources) > 0 {
+		b.WriteString("\n" + _resourcesTag)
+		for _, r := range m.Resources {
+			b.WriteString("\n- " + r)
+		}
+	}
+	return b.String()
+}
+
+// ParseMessage parses the message in the format of EIP-4361. The message can say either IoTeX or Ethereum account,
+// and the address can be in the io1 or the 0x form.
+func ParseMessage(s string) (*Message, error) {
+	lines := strings.Split(s, "\n")
+	p := &parser{lines: lines}
+	m := &Message{}
+
+	header := p.next()
+	switch {
+	case strings.HasSuffix(header, _header):
+		m.Domain = strings.TrimSuffix(header, _header)
+	case strings.HasSuffix(header, _ethHeader):
+		m.Domain = strings.TrimSuffix(header, _ethHeader)
+	default:
+		return nil, p.errorf("missing header")
+	}
+	if m.Domain == "" {
+		return nil, p.errorf("missing domain")
+	}
+	addr := p.next()
+	var err error
+	if strings.HasPrefix(addr, "0x") {
+		if !common.IsHexAddress(addr) {
+			return nil, p.errorf("invalid address %s", addr)
+		}
+		m.EthAddress = true
+		m.Address, err = address.FromBytes(common.HexToAddress(addr).Bytes())
+	} else {
+		m.Address, err = address.FromString(addr)
+	}
+	if err != nil {
+		return nil, p.errorf("invalid address %s", addr)
+	}
+	if p.next() != "" {
+		return nil, p.errorf("missing empty line")
+	}
+	if line := p.next(); line != "" {
+		m.Statement = line
+		if p.next() != "" {
+			return nil, p.errorf("missing empty line")
+		}
+	}
+	if m.URI, err = p.tag(_uriTag, true); err != nil {
+		return nil, err
+	}
+	if m.Version, err = p.tag(_versionTag, true); err != nil {
+		return nil, err
+	}
+	if m.Version != Version {
+		return nil, p.errorf("unsupported version %s", m.Version)
+	}
+	chainID, err := p.tag(_chainIDTag, true)
+	if err != nil {
+		return nil, err
+	}
+	id, err := strconv.ParseUint(chainID, 10, 32)
+	if err != nil {
+		return nil, p.errorf("invalid chain ID %s", chainID)
+	}
+	m.ChainID = uint32(id)
+	if m.Nonce, err = p.tag(_nonceTag, true); err != nil {
+		return nil, err
+	}
+	if len(m.Nonce) < 8 {
+		return nil, p.errorf("nonce is shorter than 8 characters")
+	}
+	if m.IssuedAt, err = p.time(_issuedAtTag, true); err != nil {
+		return nil, err
+	}
+	if m.ExpirationTime, err = p.time(_expirationTimeTag, false); err != nil {
+		return nil, err
+	}
+	if m.NotBefore, err = p.time(_notBeforeTag, false); err != nil {
+		return nil, err
+	}
+	if m.RequestID, err = p.tag(_requestIDTag, false); err != nil {
+		return nil, err
+	}
+	if p.peek() == _resourcesTag {
+		p.next()
+		for p.i < len(p.lines) && strings.HasPrefix(p.peek(), "- ") {
+			m.Resources = append(m.Resources, strings.TrimPrefix(p.next(), "- "))
+		}
+	}
+	if p.i < len(p.lines) {
+		return nil, p.errorf("unexpected line %q", p.peek())
+	}
+	return m, nil
+}
+
+type parser struct {
+	lines []string
+	i     int
+}
+
+func (p *parser) peek() string {
+	if p.i >= len(p.lines) {
+		return ""
+	}
+	return p.lines[p.i]
+}
+
+func (p *parser) next() string {
+	line := p.peek()
+	p.i++
+	return line
+}
+
+func (p *parser) errorf(format string, args ...interface{}) error {
+	return errors.Wrapf(ErrInvalidMessage, "line %d: %s", p.i, fmt.Sprintf(format, args...))
+}
+
+func (p *parser) tag(tag string, required bool) (string, error) {
+	if p.i < len(p.lines) && strings.HasPrefix(p.peek(), tag) {
+		return strings.TrimPrefix(p.next(), tag), nil
+	}
+	if required {
+		return "", p.errorf("missing %s", strings.TrimSuffix(tag, ": "))
+	}
+	return "", nil
+}
+
+func (p *parser) time(tag string, required bool) (time.Time, error) {
+	v, err := p.tag(tag, required)
+	if err != nil || v == "" {
+		return time.Time{}, err
+	}
+	t, err := time.Parse(time.RFC3339, v)
+	if err != nil {
+		return time.Time{}, p.errorf("invalid %s%s", tag, v)
+	}
+	return t, nil
+}
@@ -0,0 +1,86 @@
+// Copyright (c) 2020 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package siwe
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+// the example of EIP-4361
+const _ethMessage = `service.org wants you to sign in with your Ethereum account:
+0xe5A12547fe4E872D192E3eCecb76F2Ce1aeA4946
+
+I accept the ServiceOrg Terms of Service: https://service.org/tos
+
+URI: https://service.org/login
+Version: 1
+Chain ID: 1
+Nonce: 32891756
+Issued At: 2021-09-30T16:25:24Z
+Resources:
+- ipfs://bafybeiemxf5abjwjbikoz4mc3a3dla6ual3jsgpdr4cjr3oz3evfyavhwq/
+- https://example.com/my-web2-claim.json`
+
+func TestParseMessage(t *testing.T) {
+	require := require.New(t)
+	m, err := ParseMessage(_ethMessage)
+	require.NoError(err)
+	require.Equal("service.org", m.Domain)
+	require.True(m.EthAddress)
+	require.Equal("I accept the ServiceOrg Terms of Service: https://service.org/tos", m.Statement)
+	require.Equal("https://service.org/login", m.URI)
+	require.EqualValues(1, m.ChainID)
+	require.Equal("32891756", m.Nonce)
+	require.Equal(time.Date(2021, 9, 30, 16, 25, 24, 0, time.UTC), m.IssuedAt)
+	require.Len(m.Resources, 2)
+
+	// the message says IoTeX account when written
+	m.Domain = "example.com"
+	m.Statement = ""
+	m.EthAddress = false
+	m.ChainID = 4689
+	m.ExpirationTime = m.IssuedAt.Add(time.Hour)
+	m.NotBefore = m.IssuedAt
+	m.RequestID = "42"
+	m.Resources = nil
+	s := m.String()
+	require.Equal(`example.com wants you to sign in with your IoTeX account:
+`+m.Address.String()+`
+
+
+URI: https://service.org/login
+Version: 1
+Chain ID: 4689
+Nonce: 32891756
+Issued At: 2021-09-30T16:25:24Z
+Expiration Time: 2021-09-30T17:25:24Z
+Not Before: 2021-09-30T16:25:24Z
+Request ID: 42`, s)
+	parsed, err := ParseMessage(s)
+	require.NoError(err)
+	require.Equal(m, parsed)
+
+	for _, invalid := range []string{
+		"",
+		"service.org wants you to sign in with your account:\n0xe5A12547fe4E872D192E3eCecb76F2Ce1aeA4946",
+		"service.org wants you to sign in with your Ethereum account:\nio1invalid\n\n\nURI: a",
+		s[:len(s)-1] + "\nExtra: line",
+		s[:len(s)-len("Request ID: 42")-1],
+	} {
+		_, err := ParseMessage(invalid)
+		if invalid == s[:len(s)-len("Request ID: 42")-1] {
+			// the optional fields can be omitted
+			require.NoError(err)
+			continue
+		}
+		require.True(errors.Is(err, ErrInvalidMessage))
+	}
+}
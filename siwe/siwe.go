@@ -0,0 +1,232 @@
+// Copyright (c) 2020 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package siwe
+
+import (
+	"context"
+	"crypto/rand"
+	"math/big"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/iotexproject/iotex-antenna-go/v2/account"
+	"github.com/iotexproject/iotex-antenna-go/v2/jwt"
+)
+
+const (
+	_nonceAlphabet = "0123456789abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ"
+	_nonceLength   = 17
+)
+
+var (
+	// ErrInvalidNonce is returned when the nonce of the message is not issued, expired or already used
+	ErrInvalidNonce = errors.New("invalid or used nonce")
+	// ErrInvalidSignature is returned when the message is not signed by its address
+	ErrInvalidSignature = errors.New("invalid signature")
+	// ErrExpired is returned when the message is expired or not yet valid
+	ErrExpired = errors.New("message expired or not yet valid")
+)
+
+type (
+	// NonceStore keeps the issued nonces until they are used or expired. A nonce can be used only once.
+	NonceStore interface {
+		// Put stores the issued nonce, valid until the expiry
+		Put(ctx context.Context, nonce string, expiry time.Time) error
+		// Use deletes the nonce, and returns false if it is not issued, expired or already used. It must be atomic
+		// for the nonce, as a message signed once can be sent in concurrent requests.
+		Use(ctx context.Context, nonce string) (bool, error)
+	}
+
+	// Option is an option of the Service
+	Option func(*Service)
+
+	// Service issues the nonces of the sign-in messages, verifies the signed messages, and issues the session tokens
+	Service struct {
+		domain     string
+		chainID    uint32
+		nonces     NonceStore
+		signer     account.Signer
+		nonceTTL   time.Duration
+		sessionTTL time.Duration
+	}
+
+	memoryNonceStore struct {
+		mutex  sync.Mutex
+		nonces map[string]time.Time
+	}
+)
+
+// NewNonce returns a random alphanumeric nonce of 17 characters, about 100 bits
+func NewNonce() (string, error) {
+	max := big.NewInt(int64(len(_nonceAlphabet)))
+	b := make([]byte, _nonceLength)
+	for i := range b {
+		n, err := rand.Int(rand.Reader, max)
+		if err != nil {
+			return "", err
+		}
+		b[i] = _nonceAlphabet[n.Int64()]
+	}
+	return string(b), nil
+}
+
+// NewMemoryNonceStore creates a NonceStore in memory, for a single server. The expired nonces are removed when new
+// ones are put.
+func NewMemoryNonceStore() NonceStore {
+	return &memoryNonceStore{nonces: make(map[string]time.Time)}
+}
+
+func (s *memoryNonceStore) Put(_ context.Context, nonce string, expiry time.Time) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	now := time.Now()
+	for n, e := range s.nonces {
+		if now.After(e) {
+			delete(s.nonces, n)
+		}
+	}
+	s.nonces[nonce] = expiry
+	return nil
+}
+
+func (s *memoryNonceStore) Use(_ context.Context, nonce string) (bool, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	expiry, ok := s.nonces[nonce]
+	if !ok {
+		return false, nil
+	}
+	delete(s.nonces, nonce)
+	return !time.Now().After(expiry), nil
+}
+
+// WithNonceTTL sets the time a nonce is valid after issued, default is 10 minutes
+func WithNonceTTL(ttl time.Duration) Option {
+	return func(s *Service) {
+		s.nonceTTL = ttl
+	}
+}
+
+// WithSessionTTL sets the time a session token is valid, default is 24 hours. The token expires no later than the
+// expiration time of the message.
+func WithSessionTTL(ttl time.Duration) Option {
+	return func(s *Service) {
+		s.sessionTTL = ttl
+	}
+}
+
+// NewService creates the sign-in service of the domain on the chain of the EVM network ID, which signs the session
+// tokens with the signer
+func NewService(domain string, chainID uint32, nonces NonceStore, signer account.Signer, opts ...Option) *Service {
+	s := &Service{
+		domain:     domain,
+		chainID:    chainID,
+		nonces:     nonces,
+		signer:     signer,
+		nonceTTL:   10 * time.Minute,
+		sessionTTL: 24 * time.Hour,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// Nonce issues a new nonce, to be put in the message by the client
+func (s *Service) Nonce(ctx context.Context) (string, error) {
+	nonce, err := NewNonce()
+	if err != nil {
+		return "", err
+	}
+	if err := s.nonces.Put(ctx, nonce, time.Now().Add(s.nonceTTL)); err != nil {
+		return "", err
+	}
+	return nonce, nil
+}
+
+// Verify parses the message, checks its domain, chain ID and validity time, and verifies it is signed by its address,
+// with either the preamble of Ethereum personal_sign or the preamble of IoTeX. The nonce is used up once the message
+// is verified.
+func (s *Service) Verify(ctx context.Context, message string, sig []byte) (*Message, error) {
+	m, err := ParseMessage(message)
+	if err != nil {
+		return nil, err
+	}
+	if m.Domain != s.domain {
+		return nil, errors.Wrapf(ErrInvalidMessage, "domain %s is not %s", m.Domain, s.domain)
+	}
+	if m.ChainID != s.chainID {
+		return nil, errors.Wrapf(ErrInvalidMessage, "chain ID %d is not %d", m.ChainID, s.chainID)
+	}
+	now := time.Now()
+	if (!m.ExpirationTime.IsZero() && !now.Before(m.ExpirationTime)) ||
+		(!m.NotBefore.IsZero() && now.Before(m.NotBefore)) {
+		return nil, ErrExpired
+	}
+	if !signedBy([]byte(message), sig, m) {
+		return nil, ErrInvalidSignature
+	}
+	ok, err := s.nonces.Use(ctx, m.Nonce)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, ErrInvalidNonce
+	}
+	return m, nil
+}
+
+// Login verifies the signed message, and issues a session token by jwt.SignJWTWithSigner, with the address as the
+// subject and the resources separated by spaces as the scope
+func (s *Service) Login(ctx context.Context, message string, sig []byte) (string, *Message, error) {
+	m, err := s.Verify(ctx, message, sig)
+	if err != nil {
+		return "", nil, err
+	}
+	now := time.Now()
+	expire := now.Add(s.sessionTTL)
+	if !m.ExpirationTime.IsZero() && m.ExpirationTime.Before(expire) {
+		expire = m.ExpirationTime
+	}
+	token, err := jwt.SignJWTWithSigner(now.Unix(), expire.Unix(), m.Address.String(), strings.Join(m.Resources, " "), s.signer)
+	if err != nil {
+		return "", nil, err
+	}
+	return token, m, nil
+}
+
+// Issuer returns the issuer of the session tokens, to be trusted by jwt.Middleware and the jwt interceptors
+func (s *Service) Issuer() string {
+	return s.signer.Address().String()
+}
+
+// VerifySession verifies the session token is issued by the service, as anyone can issue a valid token with the
+// address as the subject by their own key
+func (s *Service) VerifySession(token string, opts ...jwt.VerifyOption) (*jwt.JWT, error) {
+	return jwt.VerifyJWT(token, append(opts, jwt.WithIssuers(s.Issuer()))...)
+}
+
+func signedBy(message, sig []byte, m *Message) bool {
+	if len(sig) != 65 {
+		return false
+	}
+	sig = append([]byte{}, sig...)
+	if sig[64] >= 27 {
+		sig[64] -= 27
+	}
+	ethHash, iotexHash := account.HashEthMessage(message), account.HashMessage(message)
+	for _, h := range [][]byte{ethHash[:], iotexHash[:]} {
+		addr, err := account.RecoverAddress(h, sig)
+		if err == nil && addr.String() == m.Address.String() {
+			return true
+		}
+	}
+	return false
+}
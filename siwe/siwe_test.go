@@ -0,0 +1,181 @@
+// Copyright (c) 2020 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package siwe
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-antenna-go/v2/account"
+	"github.com/iotexproject/iotex-antenna-go/v2/jwt"
+)
+
+func TestLogin(t *testing.T) {
+	require := require.New(t)
+	server, err := account.NewAccount()
+	require.NoError(err)
+	serverSigner, err := account.AsSigner(server)
+	require.NoError(err)
+	svc := NewService("example.com", 4689, NewMemoryNonceStore(), serverSigner, WithSessionTTL(time.Hour))
+	user, err := account.NewAccount()
+	require.NoError(err)
+	userSigner, err := account.AsSigner(user)
+	require.NoError(err)
+
+	ctx := context.Background()
+	newMessage := func() *Message {
+		nonce, err := svc.Nonce(ctx)
+		require.NoError(err)
+		return &Message{
+			Domain:    "example.com",
+			Address:   user.Address(),
+			Statement: "Sign in to Example",
+			URI:       "https://example.com/login",
+			Version:   Version,
+			ChainID:   4689,
+			Nonce:     nonce,
+			IssuedAt:  time.Now(),
+			Resources: []string{jwt.READ, jwt.UPDATE},
+		}
+	}
+
+	// signed by MetaMask in the 0x form
+	m := newMessage()
+	m.EthAddress = true
+	m.ExpirationTime = time.Now().Add(10 * time.Minute)
+	sig, err := account.SignEthMessage(userSigner, []byte(m.String()))
+	require.NoError(err)
+	token, verified, err := svc.Login(ctx, m.String(), sig)
+	require.NoError(err)
+	require.Equal(user.Address().String(), verified.Address.String())
+	claims, err := svc.VerifySession(token)
+	require.NoError(err)
+	require.Equal(user.Address().String(), claims.Subject)
+	require.Equal("Read Update", claims.Scope)
+	require.Equal("0x"+server.PublicKey().HexString(), claims.Issuer)
+	require.Equal(m.ExpirationTime.Unix(), claims.ExpiresAt)
+
+	// a session token issued by another key is rejected
+	forged, err := jwt.SignJWTWithSigner(time.Now().Unix(), time.Now().Add(time.Hour).Unix(), user.Address().String(), "Read Update", userSigner)
+	require.NoError(err)
+	_, err = jwt.VerifyJWT(forged)
+	require.NoError(err)
+	_, err = svc.VerifySession(forged)
+	require.Error(err)
+	middleware, err := jwt.Middleware(jwt.READ, []string{svc.Issuer()})
+	require.NoError(err)
+	handler := middleware(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}))
+	for token, code := range map[string]int{token: http.StatusOK, forged: http.StatusUnauthorized} {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		require.Equal(code, rec.Code)
+	}
+
+	// the nonce is used once
+	_, _, err = svc.Login(ctx, m.String(), sig)
+	require.Equal(ErrInvalidNonce, err)
+
+	// signed by ioPay in the io1 form
+	m = newMessage()
+	sig, err = user.SignMessage([]byte(m.String()))
+	require.NoError(err)
+	_, _, err = svc.Login(ctx, m.String(), sig)
+	require.NoError(err)
+
+	// concurrent requests of a message succeed only once
+	m = newMessage()
+	sig, err = account.SignEthMessage(userSigner, []byte(m.String()))
+	require.NoError(err)
+	var (
+		wg        sync.WaitGroup
+		mutex     sync.Mutex
+		successes int
+	)
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := svc.Verify(ctx, m.String(), sig); err == nil {
+				mutex.Lock()
+				successes++
+				mutex.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+	require.Equal(1, successes)
+
+	// invalid messages
+	other, err := account.NewAccount()
+	require.NoError(err)
+	m = newMessage()
+	sig, err = other.SignMessage([]byte(m.String()))
+	require.NoError(err)
+	_, err = svc.Verify(ctx, m.String(), sig)
+	require.Equal(ErrInvalidSignature, err)
+	// the nonce is not used by an invalid signature
+	sig, err = user.SignMessage([]byte(m.String()))
+	require.NoError(err)
+	_, err = svc.Verify(ctx, m.String(), sig)
+	require.NoError(err)
+
+	m = newMessage()
+	m.Nonce = "unissued-nonce"
+	sig, err = user.SignMessage([]byte(m.String()))
+	require.NoError(err)
+	_, err = svc.Verify(ctx, m.String(), sig)
+	require.Equal(ErrInvalidNonce, err)
+
+	m = newMessage()
+	m.ExpirationTime = time.Now().Add(-time.Second)
+	sig, err = user.SignMessage([]byte(m.String()))
+	require.NoError(err)
+	_, err = svc.Verify(ctx, m.String(), sig)
+	require.Equal(ErrExpired, err)
+
+	for _, f := range []func(*Message){
+		func(m *Message) { m.Domain = "evil.com" },
+		func(m *Message) { m.ChainID = 1 },
+	} {
+		m = newMessage()
+		f(m)
+		sig, err = user.SignMessage([]byte(m.String()))
+		require.NoError(err)
+		_, err = svc.Verify(ctx, m.String(), sig)
+		require.True(errors.Is(err, ErrInvalidMessage))
+	}
+}
+
+func TestMemoryNonceStore(t *testing.T) {
+	require := require.New(t)
+	s := NewMemoryNonceStore()
+	ctx := context.Background()
+	require.NoError(s.Put(ctx, "expired", time.Now().Add(-time.Second)))
+	require.NoError(s.Put(ctx, "valid", time.Now().Add(time.Minute)))
+	ok, err := s.Use(ctx, "expired")
+	require.NoError(err)
+	require.False(ok)
+	ok, err = s.Use(ctx, "valid")
+	require.NoError(err)
+	require.True(ok)
+	ok, err = s.Use(ctx, "valid")
+	require.NoError(err)
+	require.False(ok)
+
+	nonce, err := NewNonce()
+	require.NoError(err)
+	require.Len(nonce, 17)
+}
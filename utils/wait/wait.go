@@ -3,37 +3,265 @@ package wait
 import (
 	"context"
 	"encoding/hex"
+	"fmt"
 	"time"
 
 	"github.com/cenkalti/backoff"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/iotexproject/go-pkgs/hash"
 	"github.com/iotexproject/iotex-antenna-go/v2/iotex"
 	"github.com/iotexproject/iotex-proto/golang/iotexapi"
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
 	"github.com/pkg/errors"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
-// Wait waits on a send action caller to finish (wait 20 second), then fetch the receipt of the action,
-// to make sure the action is on chain.
+// DefaultTimeout is the time Wait waits for the receipt, if the context has no deadline
+const DefaultTimeout = 2 * time.Minute
+
+type (
+	// Option is an option of WaitForReceipt
+	Option func(*options)
+
+	options struct {
+		interval      time.Duration
+		multiplier    float64
+		maxInterval   time.Duration
+		stream        bool
+		confirmations uint64
+		abi           *abi.ABI
+		callOpts      []grpc.CallOption
+	}
+
+	// ReceiptError is returned when the action is on chain but its execution failed
+	ReceiptError struct {
+		Status  iotextypes.ReceiptStatus
+		Receipt *iotextypes.Receipt
+		// Revert is the decoded revert reason
+		Revert *iotex.RevertError
+	}
+)
+
+func (e *ReceiptError) Error() string {
+	if e.Revert != nil && e.Revert.Name != "" {
+		return fmt.Sprintf("action %x failed: %s", e.Receipt.GetActHash(), e.Revert.Error())
+	}
+	return fmt.Sprintf("action %x failed with status %d (%s)", e.Receipt.GetActHash(), e.Status, e.Status)
+}
+
+// Unwrap returns the decoded revert reason
+func (e *ReceiptError) Unwrap() error {
+	if e.Revert == nil {
+		return nil
+	}
+	return e.Revert
+}
+
+// WithInterval sets the interval of polling the receipt, default is 5 seconds
+func WithInterval(d time.Duration) Option {
+	return func(o *options) {
+		o.interval = d
+	}
+}
+
+// WithBackoff grows the polling interval by multiplier after each poll, up to maxInterval
+func WithBackoff(multiplier float64, maxInterval time.Duration) Option {
+	return func(o *options) {
+		o.multiplier = multiplier
+		o.maxInterval = maxInterval
+	}
+}
+
+// WithStreamBlocks follows the new blocks from StreamBlocks, instead of polling the receipt
+func WithStreamBlocks() Option {
+	return func(o *options) {
+		o.stream = true
+	}
+}
+
+// WithConfirmations waits until n blocks are produced on top of the block containing the action
+func WithConfirmations(n uint64) Option {
+	return func(o *options) {
+		o.confirmations = n
+	}
+}
+
+// WithABI decodes custom errors declared in the contract ABI from the revert reason of a failed execution
+func WithABI(contractABI abi.ABI) Option {
+	return func(o *options) {
+		o.abi = &contractABI
+	}
+}
+
+// WithCallOptions sets the grpc call options of the API calls
+func WithCallOptions(opts ...grpc.CallOption) Option {
+	return func(o *options) {
+		o.callOpts = opts
+	}
+}
+
+// Wait waits on a send action caller to finish, then fetch the receipt of the action, to make sure the action is
+// on chain. It waits up to DefaultTimeout if ctx has no deadline.
 func Wait(ctx context.Context, caller iotex.Caller, opts ...grpc.CallOption) error {
 	h, err := caller.Call(ctx, opts...)
 	if err != nil {
 		return err
 	}
 
-	time.Sleep(25 * time.Second)
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, DefaultTimeout)
+		defer cancel()
+	}
+	_, err = WaitForReceipt(ctx, caller.API(), h, WithCallOptions(opts...))
+	return err
+}
+
+// WaitForReceipt waits until the action is on chain and returns its receipt, or returns when ctx is done.
+// If the action failed, the receipt is returned along with a *ReceiptError.
+func WaitForReceipt(ctx context.Context, api iotexapi.APIServiceClient, h hash.Hash256, opts ...Option) (*iotextypes.Receipt, error) {
+	o := options{
+		interval:   5 * time.Second,
+		multiplier: 1,
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
 
-	var response *iotexapi.GetReceiptByActionResponse
-	rerr := backoff.Retry(func() error {
-		response, err = caller.API().GetReceiptByAction(ctx, &iotexapi.GetReceiptByActionRequest{
-			ActionHash: hex.EncodeToString(h[:]),
-		}, opts...)
-		return err
-	}, backoff.WithMaxRetries(backoff.NewConstantBackOff(30*time.Second), 3))
-	if rerr != nil {
-		return errors.Errorf("execution get receipt timed out: %v", rerr)
+	var (
+		receipt *iotextypes.Receipt
+		err     error
+	)
+	if o.stream {
+		receipt, err = streamReceipt(ctx, api, h, &o)
+	} else {
+		receipt, err = pollReceipt(ctx, api, h, &o)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if receipt.GetStatus() != uint64(iotextypes.ReceiptStatus_Success) {
+		return receipt, &ReceiptError{
+			Status:  iotextypes.ReceiptStatus(receipt.GetStatus()),
+			Receipt: receipt,
+			Revert:  iotex.ReceiptRevertError(o.abi, receipt),
+		}
+	}
+	return receipt, nil
+}
+
+func pollReceipt(ctx context.Context, api iotexapi.APIServiceClient, h hash.Hash256, o *options) (*iotextypes.Receipt, error) {
+	b := &backoff.ExponentialBackOff{
+		InitialInterval: o.interval,
+		Multiplier:      o.multiplier,
+		MaxInterval:     o.maxInterval,
+		Clock:           backoff.SystemClock,
+	}
+	if b.MaxInterval < b.InitialInterval {
+		b.MaxInterval = b.InitialInterval
+	}
+	b.Reset()
+
+	var receipt *iotextypes.Receipt
+	for {
+		if receipt == nil {
+			r, err := getReceipt(ctx, api, h, o)
+			if err != nil {
+				return nil, err
+			}
+			receipt = r
+		}
+		if receipt != nil {
+			confirmed, err := isConfirmed(ctx, api, receipt, o)
+			if err != nil {
+				return nil, err
+			}
+			if confirmed {
+				return receipt, nil
+			}
+		}
+
+		timer := time.NewTimer(b.NextBackOff())
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, errors.Wrapf(ctx.Err(), "failed to wait for receipt of action %x", h)
+		case <-timer.C:
+		}
+	}
+}
+
+func streamReceipt(ctx context.Context, api iotexapi.APIServiceClient, h hash.Hash256, o *options) (*iotextypes.Receipt, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	stream, err := api.StreamBlocks(ctx, &iotexapi.StreamBlocksRequest{}, o.callOpts...)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to stream blocks")
+	}
+
+	// the action may be on chain before the stream starts
+	receipt, err := getReceipt(ctx, api, h, o)
+	if err != nil {
+		return nil, err
+	}
+	if receipt != nil {
+		confirmed, err := isConfirmed(ctx, api, receipt, o)
+		if err != nil {
+			return nil, err
+		}
+		if confirmed {
+			return receipt, nil
+		}
+	}
+	for {
+		res, err := stream.Recv()
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, errors.Wrapf(ctx.Err(), "failed to wait for receipt of action %x", h)
+			}
+			return nil, errors.Wrap(err, "failed to stream blocks")
+		}
+		height := res.GetBlock().GetBlock().GetHeader().GetCore().GetHeight()
+		if receipt == nil {
+			for _, r := range res.GetBlock().GetReceipts() {
+				if hash.BytesToHash256(r.GetActHash()) == h {
+					receipt = r
+					break
+				}
+			}
+		}
+		if receipt != nil && height >= receipt.GetBlkHeight()+o.confirmations {
+			return receipt, nil
+		}
 	}
-	if response.ReceiptInfo.Receipt.Status != 1 {
-		return errors.Errorf("execution failed: %x", h)
+}
+
+// getReceipt returns the receipt of the action, or nil if the action is not on chain yet
+func getReceipt(ctx context.Context, api iotexapi.APIServiceClient, h hash.Hash256, o *options) (*iotextypes.Receipt, error) {
+	response, err := api.GetReceiptByAction(ctx, &iotexapi.GetReceiptByActionRequest{
+		ActionHash: hex.EncodeToString(h[:]),
+	}, o.callOpts...)
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return nil, nil
+		}
+		if ctx.Err() != nil {
+			return nil, errors.Wrapf(ctx.Err(), "failed to wait for receipt of action %x", h)
+		}
+		return nil, errors.Wrap(err, "failed to get receipt")
+	}
+	return response.GetReceiptInfo().GetReceipt(), nil
+}
+
+func isConfirmed(ctx context.Context, api iotexapi.APIServiceClient, receipt *iotextypes.Receipt, o *options) (bool, error) {
+	if o.confirmations == 0 {
+		return true, nil
+	}
+	response, err := api.GetChainMeta(ctx, &iotexapi.GetChainMetaRequest{}, o.callOpts...)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to get chain meta")
 	}
-	return nil
+	return response.GetChainMeta().GetHeight() >= receipt.GetBlkHeight()+o.confirmations, nil
 }
@@ -0,0 +1,107 @@
+package wait
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/iotexproject/iotex-proto/golang/iotexapi"
+	"github.com/iotexproject/iotex-proto/golang/iotexapi/mock_iotexapi"
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var _actionHash, _ = hash.HexStringToHash256("16fbdac39a19f433b32e457eba9c64c48d9fafcdffaddb11a0b7d264c0cf1418")
+
+type blockStream struct {
+	grpc.ClientStream
+	blocks []*iotexapi.StreamBlocksResponse
+}
+
+func (s *blockStream) Recv() (*iotexapi.StreamBlocksResponse, error) {
+	if len(s.blocks) == 0 {
+		return nil, errors.New("stream closed")
+	}
+	b := s.blocks[0]
+	s.blocks = s.blocks[1:]
+	return b, nil
+}
+
+func receiptResponse(status iotextypes.ReceiptStatus, height uint64) *iotexapi.GetReceiptByActionResponse {
+	return &iotexapi.GetReceiptByActionResponse{ReceiptInfo: &iotexapi.ReceiptInfo{
+		Receipt: &iotextypes.Receipt{Status: uint64(status), BlkHeight: height, ActHash: _actionHash[:]},
+	}}
+}
+
+func TestWaitForReceipt(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	api := mock_iotexapi.NewMockAPIServiceClient(ctrl)
+	notFound := status.Error(codes.NotFound, "receipt not found")
+	gomock.InOrder(
+		api.EXPECT().GetReceiptByAction(gomock.Any(), gomock.Any()).Return(nil, notFound).Times(2),
+		api.EXPECT().GetReceiptByAction(gomock.Any(), gomock.Any()).Return(receiptResponse(iotextypes.ReceiptStatus_Success, 10), nil),
+		api.EXPECT().GetChainMeta(gomock.Any(), gomock.Any()).Return(&iotexapi.GetChainMetaResponse{
+			ChainMeta: &iotextypes.ChainMeta{Height: 11},
+		}, nil),
+		api.EXPECT().GetChainMeta(gomock.Any(), gomock.Any()).Return(&iotexapi.GetChainMetaResponse{
+			ChainMeta: &iotextypes.ChainMeta{Height: 12},
+		}, nil),
+	)
+	r, err := WaitForReceipt(context.Background(), api, _actionHash,
+		WithInterval(time.Millisecond), WithBackoff(2, 4*time.Millisecond), WithConfirmations(2))
+	require.NoError(err)
+	require.EqualValues(10, r.GetBlkHeight())
+
+	// failed receipt
+	failed := receiptResponse(iotextypes.ReceiptStatus_ErrExecutionReverted, 10)
+	failed.ReceiptInfo.Receipt.ExecutionRevertMsg = "ERC20: transfer amount exceeds balance"
+	api.EXPECT().GetReceiptByAction(gomock.Any(), gomock.Any()).Return(failed, nil)
+	r, err = WaitForReceipt(context.Background(), api, _actionHash)
+	require.NotNil(r)
+	receiptErr, ok := err.(*ReceiptError)
+	require.True(ok)
+	require.Equal(iotextypes.ReceiptStatus_ErrExecutionReverted, receiptErr.Status)
+	require.Equal("ERC20: transfer amount exceeds balance", receiptErr.Revert.Reason)
+	require.Contains(err.Error(), "execution reverted: ERC20: transfer amount exceeds balance")
+
+	// ctx deadline
+	api.EXPECT().GetReceiptByAction(gomock.Any(), gomock.Any()).Return(nil, notFound).AnyTimes()
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	_, err = WaitForReceipt(ctx, api, _actionHash, WithInterval(5*time.Millisecond))
+	require.True(errors.Is(err, context.DeadlineExceeded))
+}
+
+func TestWaitForReceiptStream(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	block := func(height uint64, receipts ...*iotextypes.Receipt) *iotexapi.StreamBlocksResponse {
+		return &iotexapi.StreamBlocksResponse{Block: &iotexapi.BlockInfo{
+			Block: &iotextypes.Block{Header: &iotextypes.BlockHeader{
+				Core: &iotextypes.BlockHeaderCore{Height: height},
+			}},
+			Receipts: receipts,
+		}}
+	}
+	receipt := receiptResponse(iotextypes.ReceiptStatus_Success, 21).GetReceiptInfo().GetReceipt()
+	api := mock_iotexapi.NewMockAPIServiceClient(ctrl)
+	api.EXPECT().StreamBlocks(gomock.Any(), gomock.Any()).Return(&blockStream{
+		blocks: []*iotexapi.StreamBlocksResponse{block(20), block(21, receipt), block(22), block(23)},
+	}, nil)
+	api.EXPECT().GetReceiptByAction(gomock.Any(), gomock.Any()).Return(nil, status.Error(codes.NotFound, ""))
+
+	r, err := WaitForReceipt(context.Background(), api, _actionHash, WithStreamBlocks(), WithConfirmations(1))
+	require.NoError(err)
+	require.Equal(receipt, r)
+}